@@ -0,0 +1,216 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Sections read into Article.Sections, every parameter is kept
+var articleSections = []string{
+	"Introduction",
+	"Monetization",
+	"Microtransactions",
+	"Save game cloud syncing",
+	"Video",
+	"Input",
+	"Audio",
+	"Network/Multiplayer",
+	"Network/Connections",
+	"API",
+	"Middleware",
+}
+
+// Infobox fields filled by row templates rather than plain values
+var infoboxRowFields = []string{"developers", "publishers", "engines", "release dates", "reception", "taxonomy"}
+
+// Parses the wikitext into a tree of text and template nodes. Comments,
+// whitespace and unknown templates or parameters are kept as they are
+func ParseWikitext(text string) []WikiNode {
+	return buildNodes(text, 0, len(text), ParseTemplates(text))
+}
+
+func buildNodes(text string, start, end int, templates []WikiTemplate) (nodes []WikiNode) {
+	cursor := start
+	for i := range templates {
+		template := &templates[i]
+		// Templates nested within one already added are skipped, as are
+		// unclosed ones
+		if template.End == 0 || template.Start < cursor || template.End > end {
+			continue
+		}
+
+		if template.Start > cursor {
+			nodes = append(nodes, WikiNode{Text: text[cursor:template.Start]})
+		}
+		nodes = append(nodes, WikiNode{Template: buildTemplateNode(text, template, templates[i+1:])})
+		cursor = template.End
+	}
+
+	if end > cursor {
+		nodes = append(nodes, WikiNode{Text: text[cursor:end]})
+	}
+	return
+}
+
+func buildTemplateNode(text string, template *WikiTemplate, templates []WikiTemplate) *WikiTemplateNode {
+	nameEnd := template.End - 2
+	if len(template.Params) != 0 {
+		nameEnd = template.Params[0].Start - 1
+	}
+
+	node := &WikiTemplateNode{
+		Name:    template.Name,
+		NameRaw: text[template.Start+2 : nameEnd],
+	}
+
+	for _, param := range template.Params {
+		arg := WikiArg{
+			Name:  param.Name,
+			Named: param.ValueStart != param.Start,
+			Value: buildNodes(text, param.ValueStart, param.ValueEnd, templates),
+		}
+		if arg.Named {
+			arg.NameRaw = text[param.Start : param.ValueStart-1]
+		}
+		node.Args = append(node.Args, arg)
+	}
+	return node
+}
+
+// Writes the nodes back to wikitext, unchanged nodes give back the exact text
+// they were parsed from
+func SerializeWikitext(nodes []WikiNode) string {
+	var output strings.Builder
+	writeNodes(&output, nodes)
+	return output.String()
+}
+
+func writeNodes(output *strings.Builder, nodes []WikiNode) {
+	for _, node := range nodes {
+		if node.Template == nil {
+			output.WriteString(node.Text)
+			continue
+		}
+
+		output.WriteString("{{" + node.Template.NameRaw)
+		for _, arg := range node.Template.Args {
+			output.WriteString("|")
+			if arg.Named {
+				output.WriteString(arg.NameRaw + "=")
+			}
+			writeNodes(output, arg.Value)
+		}
+		output.WriteString("}}")
+	}
+}
+
+// Reads the data of the templates the generator writes back out of an article
+func ParseArticle(text string) Article {
+	article := Article{
+		Infobox:            make(map[string]string),
+		ReleaseDates:       make(map[string]string),
+		Reception:          make(map[string]Rating),
+		Taxonomy:           make(map[string]string),
+		Stores:             make(map[string]Store),
+		Languages:          make(map[string]LanguageData),
+		SystemRequirements: make(map[string]map[string]string),
+		Sections:           make(map[string]map[string]string),
+	}
+
+	for _, template := range ParseTemplates(text) {
+		name := strings.ReplaceAll(template.Name, "_", " ")
+		value := func(param string) string {
+			if p := template.Param(param); p != nil {
+				return p.Value
+			}
+			return ""
+		}
+
+		switch {
+		case templateNameIs(name, "Infobox game"):
+			for _, param := range template.Params {
+				if !containsString(infoboxRowFields, param.Name) {
+					article.Infobox[param.Name] = param.Value
+				}
+			}
+		case templateNameIs(name, "Infobox game/row/developer"):
+			article.Developers = append(article.Developers, value("1"))
+		case templateNameIs(name, "Infobox game/row/publisher"):
+			article.Publishers = append(article.Publishers, value("1"))
+		case templateNameIs(name, "Infobox game/row/engine"):
+			article.Engines = append(article.Engines, value("1"))
+		case templateNameIs(name, "Infobox game/row/date"):
+			article.ReleaseDates[value("1")] = value("2")
+		case templateNameIs(name, "Infobox game/row/reception"):
+			score, _ := strconv.Atoi(value("3"))
+			article.Reception[value("1")] = Rating{Score: score, URL: value("2")}
+		case strings.HasPrefix(strings.ToLower(name), "infobox game/row/taxonomy/"):
+			article.Taxonomy[name[len("Infobox game/row/taxonomy/"):]] = value("1")
+		case templateNameIs(name, "Availability/row"):
+			store := Store{URL: value("2"), Platforms: value("6")}
+			for _, drm := range strings.Split(value("3"), ",") {
+				if drm = strings.TrimSpace(drm); len(drm) != 0 {
+					store.DRM = append(store.DRM, drm)
+				}
+			}
+			article.Stores[value("1")] = store
+		case templateNameIs(name, "L10n/switch"):
+			article.Languages[value("language")] = LanguageData{
+				UI:        strings.EqualFold(value("interface"), "true"),
+				Audio:     strings.EqualFold(value("audio"), "true"),
+				Subtitles: strings.EqualFold(value("subtitles"), "true"),
+			}
+		case templateNameIs(name, "System requirements"):
+			article.SystemRequirements[value("OSfamily")] = templateValues(&template, "OSfamily")
+		default:
+			for _, section := range articleSections {
+				if templateNameIs(name, section) {
+					article.Sections[section] = templateValues(&template, "")
+				}
+			}
+		}
+	}
+	return article
+}
+
+func templateValues(template *WikiTemplate, skip string) map[string]string {
+	values := make(map[string]string)
+	for _, param := range template.Params {
+		if param.Name != skip {
+			values[param.Name] = param.Value
+		}
+	}
+	return values
+}
+
+// Parses an article file, prints its data as JSON and checks that it
+// serializes back to the exact same text
+func RunParse(args []string) {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: parse <wikitext file>")
+		return
+	}
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		Log.Errorf("Failed to read the article... (%s)", err)
+		return
+	}
+	text := string(data)
+
+	output, err := json.MarshalIndent(ParseArticle(text), "", "  ")
+	if err != nil {
+		Log.Errorf("Failed to encode the article data... (%s)", err)
+		return
+	}
+	fmt.Println(string(output))
+
+	if SerializeWikitext(ParseWikitext(text)) != text {
+		Log.Errorf("The article does not serialize back to the same wikitext!")
+		os.Exit(1)
+	}
+	Log.Infof("The article serializes back to the same wikitext")
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+// Articles edited by hand, the generated ones are written by the tests
+var editedFixtures = []string{"testdata/edited.txt"}
+
+func readFixture(t *testing.T, fileName string) string {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// A game with data for every template ParseArticle reads
+func newArticleGame() Game {
+	game := newTestGame("Portal 2")
+	game.Data.Developers = []string{"Valve"}
+	game.Data.Publishers = []string{"Electronic Arts"}
+	game.Data.Platforms = Platforms{Windows: true, MAC: true}
+	game.Data.ReleaseDate = ReleaseDate{Date: "18 Apr, 2011"}
+	game.Data.SupportedLanguages = "English<strong>*</strong>, French<strong>*</strong>, German, Simplified Chinese<br><strong>*</strong>languages with full audio support"
+	game.ProcessLanguages()
+	game.Data.PCRequirements = Requirement{"minimum": "<strong>Minimum:</strong><br><ul class=\"bb_ul\"><li><strong>OS:</strong> Windows 7<br></li><li><strong>Memory:</strong> 2 GB RAM</li></ul>"}
+	game.Data.MACRequirements = Requirement{"minimum": "<strong>Minimum:</strong><br><ul class=\"bb_ul\"><li><strong>OS:</strong> OS X 10.11<br></li><li><strong>Memory:</strong> 4 GB RAM</li></ul>"}
+	game.Data.Categories = []Category{{ID: int64(Singleplayer)}, {ID: int64(Multiplayer)}}
+	game.Data.Packages = []int64{7877}
+	game.Data.PackageGroups = []PackageGroup{{Subs: []Sub{{PackageID: 7877, OptionText: "Portal 2 - $9.99"}}}}
+	game.Data.Metacritic = &Rating{Score: 95, URL: "https://www.metacritic.com/game/pc/portal-2?ftag=MCD-06-10aaa1f"}
+	game.Data.Ratings["OpenCritic"] = Rating{Score: 92, URL: "https://opencritic.com/game/1548/portal-2"}
+	game.SetFranchise("Portal")
+	game.SetTaxonomy([]string{"Puzzle", "First-Person", "Sci-fi", "Great Soundtrack"})
+	game.Data.Stores["GOG.com"] = Store{URL: "portal_2", DRM: []string{"DRM Free"}, Platforms: "Windows, OS X"}
+	game.Data.Stores["Humble"] = Store{URL: "portal-2", Platforms: "Windows"}
+	return game
+}
+
+// A free game with little more than a name on Steam
+func newSparseArticleGame() Game {
+	game := newTestGame("Jam Game")
+	game.Data.IsFree = true
+	game.Data.Developers = []string{"Solo Developer"}
+	game.Data.Platforms = Platforms{Windows: true}
+	game.Data.SupportedLanguages = "English"
+	game.ProcessLanguages()
+	game.Data.PCRequirements = Requirement{"minimum": "<strong>Minimum:</strong><br><ul class=\"bb_ul\"><li><strong>OS:</strong> Windows 10</li></ul>"}
+	return game
+}
+
+// The article game along with every feature category, full system
+// requirements and the IDs found through Wikidata and IGDB
+func newRichArticleGame() Game {
+	game := newArticleGame()
+	game.Data.Platforms.Linux = true
+	game.Data.Dlc = []int64{323180}
+	for _, category := range []CategoryId{CoOp, OnlineCoOp, LocalCoOp, SharedOrSplitScreen, Captions, Commentary, SteamAchievements, SteamCloud, FullControllerSupport, Workshop, LevelEditor} {
+		game.Data.Categories = append(game.Data.Categories, Category{ID: int64(category)})
+	}
+	game.Data.PCRequirements = Requirement{
+		"minimum":     "<strong>Minimum:</strong><br><ul class=\"bb_ul\"><li><strong>OS:</strong> Windows 7<br></li><li><strong>Processor:</strong> 3.0 GHz P4, Dual Core 2.0 or AMD64X2<br></li><li><strong>Memory:</strong> 2 GB RAM<br></li><li><strong>Graphics:</strong> Video card must be 128 MB or more<br></li><li><strong>DirectX:</strong> Version 9.0c<br></li><li><strong>Storage:</strong> 8 GB available space<br></li><li><strong>Sound Card:</strong> DirectX 9.0c compatible</li></ul>",
+		"recommended": "<strong>Recommended:</strong><br><ul class=\"bb_ul\"><li><strong>OS:</strong> Windows 10<br></li><li><strong>Processor:</strong> Intel Core i5<br></li><li><strong>Memory:</strong> 8 GB RAM<br></li><li><strong>Graphics:</strong> GeForce GTX 960<br></li><li><strong>Storage:</strong> 8 GB available space</li></ul>",
+	}
+	game.Data.LinuxRequirements = Requirement{"minimum": "<strong>Minimum:</strong><br><ul class=\"bb_ul\"><li><strong>OS:</strong> Ubuntu 12.04<br></li><li><strong>Processor:</strong> Dual core at 2.8 GHz<br></li><li><strong>Memory:</strong> 4 GB RAM<br></li><li><strong>Graphics:</strong> GeForce 8600 or Radeon HD 2600<br></li><li><strong>Storage:</strong> 8 GB available space</li></ul>"}
+	game.Data.ExternalIDs = map[string]string{"item": "Q279744", "hltb": "7231", "lutris": "portal-2", "mobygames": "portal-2", "strategywiki": "Portal 2", "wikipedia": "Portal 2", "winehq": "12345"}
+	game.Data.IGDB = &IGDBRating{Score: 91, Slug: "portal-2"}
+	return game
+}
+
+// Articles generated from the sparse, the article and the rich games
+func generatedArticles() map[string]string {
+	articles := make(map[string]string)
+	for name, game := range map[string]Game{"sparse": newSparseArticleGame(), "typical": newArticleGame(), "rich": newRichArticleGame()} {
+		articles[name] = GenerateArticle("620", &game)
+	}
+	return articles
+}
+
+func TestParseArticle(t *testing.T) {
+	config := AppConfig
+	AppConfig.Placeholders = false
+	defer func() { AppConfig = config }()
+
+	game := newArticleGame()
+	article := ParseArticle(GenerateArticle("620", &game))
+
+	compare := func(field string, got, want interface{}) {
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s = %+v, want %+v", field, got, want)
+		}
+	}
+
+	compare("developers", article.Developers, []string{"Valve"})
+	compare("publishers", article.Publishers, []string{"Electronic Arts"})
+	compare("release dates", article.ReleaseDates, map[string]string{"Windows": "Apr 18 2011", "OS X": "Apr 18 2011"})
+	compare("reception", article.Reception, map[string]Rating{
+		"Metacritic": {Score: 95, URL: "portal-2"},
+		"OpenCritic": {Score: 92, URL: "1548/portal-2"},
+	})
+	// Pacing, controls and art styles fall back to their defaults without tags
+	compare("taxonomy", article.Taxonomy, map[string]string{
+		"monetization":      "One-time game purchase",
+		"microtransactions": "None",
+		"modes":             "Singleplayer, Multiplayer",
+		"pacing":            "Real-time",
+		"perspectives":      "First-person",
+		"controls":          "Direct control",
+		"genres":            "Puzzle",
+		"sports":            "",
+		"vehicles":          "",
+		"art styles":        "Realistic",
+		"themes":            "Sci-fi",
+		"series":            "Portal",
+	})
+	compare("stores", article.Stores, map[string]Store{
+		"Steam":   {URL: "620", DRM: []string{"Steam"}, Platforms: "Windows, OS X"},
+		"GOG.com": {URL: "portal_2", DRM: []string{"DRM-free"}, Platforms: "Windows, OS X"},
+		"Humble":  {URL: "portal-2", DRM: []string{"Steam"}, Platforms: "Windows"},
+	})
+	compare("languages", article.Languages, map[string]LanguageData{
+		"English":            {UI: true, Audio: true, Subtitles: true},
+		"French":             {UI: true, Audio: true, Subtitles: true},
+		"German":             {UI: true, Audio: false, Subtitles: true},
+		"Simplified Chinese": {UI: true, Audio: false, Subtitles: true},
+	})
+
+	for field, want := range map[string]string{"cover": "Portal 2 cover.jpg", "steam appid": "620", "license": "commercial"} {
+		compare("infobox "+field, article.Infobox[field], want)
+	}
+	for family, minOS := range map[string]string{"Windows": "7", "OS X": "OS X 10.11"} {
+		compare(family+" minOS", article.SystemRequirements[family]["minOS"], minOS)
+	}
+}
+
+func TestSerializeWikitextRoundTrip(t *testing.T) {
+	texts := generatedArticles()
+	for _, fixture := range editedFixtures {
+		texts[fixture] = readFixture(t, fixture)
+	}
+
+	for name, text := range texts {
+		if got := SerializeWikitext(ParseWikitext(text)); got != text {
+			t.Errorf("%s does not serialize back to the same wikitext, got:\n%s", name, got)
+		}
+	}
+}
+
+func TestParseWikitextUnbalanced(t *testing.T) {
+	for _, text := range []string{
+		"{{a|x={{b|y",
+		"{{a|x={{b|y}}",
+		"{{a|{{b|{{c}}|d",
+		"}}{{",
+		"{{",
+		"{{a|[[b|c}}",
+		"{{a|<!-- }} -->",
+	} {
+		if got := SerializeWikitext(ParseWikitext(text)); got != text {
+			t.Errorf("%q serializes to %q", text, got)
+		}
+	}
+
+	// The closed template nested in an unclosed one is still found
+	nodes := ParseWikitext("{{a|x={{b|y}}")
+	if len(nodes) != 2 || nodes[0].Text != "{{a|x=" || nodes[1].Template == nil || nodes[1].Template.Name != "b" {
+		t.Errorf("nodes = %+v, want the text then {{b}}", nodes)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Legal suffixes, repeated so that "Co., Ltd." is stripped as a whole
+var legalSuffixRegex = regexp.MustCompile(`(?i)(,?\s+(inc|incorporated|ltd|limited|llc|l\.l\.c|gmbh|corp|corporation|co|plc|pty|s\.a|s\.a\.s|s\.r\.l|srl|sp\. z o\.o|ab|oy|b\.v|k\.k)\.?)+$`)
+
+// Loads the aliases file and the Company: pages of PCGW, which are cached for
+// a week. A resolver without pages only cleans the names up
+func LoadCompanyResolver(config CompaniesConfig, client *PCGWClient) (resolver CompanyResolver) {
+	resolver.Aliases = make(map[string]string)
+	if data, err := os.ReadFile(config.AliasesFile); err == nil {
+		var aliases map[string]string
+		if err = json.Unmarshal(data, &aliases); err != nil {
+			Log.Warnf("Failed to parse the company aliases... (%s)", err)
+		}
+		for alias, name := range aliases {
+			resolver.Aliases[companyKey(alias)] = name
+		}
+	}
+
+	if !config.Enabled {
+		return
+	}
+
+	fileName := "cache/companies.json"
+	var companies []string
+	if doesCacheExistOrLatest(fileName) {
+		if data, err := os.ReadFile(fileName); err == nil {
+			json.Unmarshal(data, &companies)
+		}
+	}
+
+	if len(companies) == 0 {
+		var err error
+		if companies, err = client.Companies(); err != nil {
+			Log.Warnf("Failed to fetch the PCGW company pages... (%s)", err)
+			return
+		}
+		data, _ := json.Marshal(companies)
+		os.WriteFile(fileName, data, 0777)
+	}
+
+	resolver.Pages = make(map[string]string)
+	for _, company := range companies {
+		resolver.Pages[companyKey(company)] = company
+	}
+	return
+}
+
+func companyKey(name string) string {
+	if key := normaliseTitle(name); len(key) != 0 {
+		return key
+	}
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Splits "Ubisoft Montreal, Ubisoft Kyiv" into both companies, while keeping
+// "Feral Interactive, Ltd." as one
+func splitCompanies(name string) (companies []string) {
+	for _, part := range strings.Split(SanitiseName(name, false), ",") {
+		part = strings.TrimSpace(part)
+		if len(part) == 0 {
+			continue
+		}
+
+		if len(companies) != 0 && legalSuffixRegex.MatchString(" "+part) && len(legalSuffixRegex.ReplaceAllString(" "+part, "")) == 0 {
+			companies[len(companies)-1] += ", " + part
+			continue
+		}
+		companies = append(companies, part)
+	}
+	return
+}
+
+func stripLegalSuffix(name string) string {
+	if stripped := strings.TrimSpace(legalSuffixRegex.ReplaceAllString(name, "")); len(stripped) != 0 {
+		return stripped
+	}
+	return name
+}
+
+// The PCGW name of every company, whether it has a Company: page is only
+// known if the pages could be loaded
+func (resolver *CompanyResolver) Resolve(names []string) (companies []string, unknown []string) {
+	for _, name := range names {
+		for _, company := range splitCompanies(name) {
+			if alias, ok := resolver.Aliases[companyKey(company)]; ok {
+				companies = appendUnique(companies, alias)
+				continue
+			}
+
+			company = stripLegalSuffix(company)
+			if alias, ok := resolver.Aliases[companyKey(company)]; ok {
+				company = alias
+			} else if page, ok := resolver.Pages[companyKey(company)]; ok {
+				company = page
+			} else if resolver.Pages != nil {
+				unknown = appendUnique(unknown, company)
+			}
+			companies = appendUnique(companies, company)
+		}
+	}
+	return
+}
+
+func (game *Game) resolveCompanies(resolver CompanyResolver) {
+	var unknown []string
+	game.Data.Developers, unknown = resolver.Resolve(game.Data.Developers)
+	game.Data.NewCompanies = appendUnique(game.Data.NewCompanies, unknown...)
+
+	game.Data.Publishers, unknown = resolver.Resolve(game.Data.Publishers)
+	game.Data.NewCompanies = appendUnique(game.Data.NewCompanies, unknown...)
+}
+
+// A comment flagging companies without a Company: page
+func (game *Game) CompanyNote(company string) string {
+	if !containsString(game.Data.NewCompanies, company) {
+		return ""
+	}
+	return formatComment("No Company:" + company + " page on PCGW yet")
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Sections of the completeness score, in article order. Sections the article
+// does not have (such as Network for singleplayer games) are not scored
+var completenessSections = []CompletenessSection{
+	{Name: "Infobox", Templates: []string{"Infobox game/row/*"}},
+	{Name: "Introduction", Templates: []string{"Introduction"}},
+	{Name: "Availability", Templates: []string{"Availability/row"}, Fields: []string{"2", "3", "6"}},
+	{Name: "Monetization", Templates: []string{"Monetization", "Microtransactions"}, Any: true},
+	{Name: "Game data", Templates: []string{"Game data/config", "Game data/saves"}, Fields: []string{"2"}},
+	{Name: "Save game cloud syncing", Templates: []string{"Save game cloud syncing"}, Fields: []string{"steam cloud"}},
+	{Name: "Video", Templates: []string{"Video"}},
+	{Name: "Input", Templates: []string{"Input"}},
+	{Name: "Audio", Templates: []string{"Audio"}},
+	{Name: "Languages", Templates: []string{"L10n/switch"}, Fields: []string{"interface", "audio", "subtitles"}},
+	{Name: "Network", Templates: []string{"Network/Multiplayer", "Network/Connections", "Network/Ports"}},
+	{Name: "API", Templates: []string{"API"}, Fields: []string{
+		"direct3d versions", "directdraw versions", "wing", "opengl versions", "glide versions",
+		"software mode", "mantle support", "metal support", "vulkan versions", "dos modes",
+	}},
+	{Name: "Executables", Templates: []string{"API"}, Fields: guessedExecutables},
+	{Name: "Middleware", Templates: []string{"Middleware"}},
+	{Name: "System requirements", Templates: []string{"System requirements"}},
+}
+
+// Scores how much of each section was filled with real data rather than left
+// empty, unknown or waiting for a citation. The article score is the average
+// of its sections, and decides (along with the section minimums) whether the
+// article is a stub
+func ScoreArticle(article string, config CompletenessConfig) (completeness Completeness) {
+	templates := ParseTemplates(article)
+
+	total := 0
+	for _, section := range completenessSections {
+		score := SectionScore{Name: section.Name}
+		for i := range templates {
+			if !section.matches(templates[i].Name) {
+				continue
+			}
+
+			for _, param := range templates[i].Params {
+				if !section.counts(param.Name) {
+					continue
+				}
+
+				score.Total++
+				if filledValue(param.Value) {
+					score.Filled++
+				}
+			}
+		}
+
+		if score.Total == 0 {
+			continue
+		}
+		if section.Any {
+			score.Total = 1
+			if score.Filled != 0 {
+				score.Filled = 1
+			}
+		}
+		score.Score = score.Filled * 100 / score.Total
+		total += score.Score
+		completeness.Sections = append(completeness.Sections, score)
+
+		if minimum, ok := config.Sections[section.Name]; ok && score.Score < minimum {
+			completeness.Reasons = append(completeness.Reasons, fmt.Sprintf("%s is below %d", section.Name, minimum))
+		}
+	}
+
+	if len(completeness.Sections) != 0 {
+		completeness.Score = total / len(completeness.Sections)
+	}
+
+	if completeness.Score < config.StubBelow {
+		completeness.Reasons = append([]string{fmt.Sprintf("the article is below %d", config.StubBelow)}, completeness.Reasons...)
+	}
+	completeness.Stub = len(completeness.Reasons) != 0
+	return
+}
+
+func (section CompletenessSection) matches(name string) bool {
+	for _, template := range section.Templates {
+		if strings.HasSuffix(template, "*") {
+			prefix := strings.TrimSuffix(template, "*")
+			if len(name) >= len(prefix) && templateNameIs(name[:len(prefix)], prefix) {
+				return true
+			}
+		} else if templateNameIs(name, template) {
+			return true
+		}
+	}
+	return false
+}
+
+func (section CompletenessSection) counts(param string) bool {
+	if len(section.Fields) != 0 {
+		return containsString(section.Fields, param)
+	}
+	return param != "notes" && !strings.HasSuffix(param, " notes")
+}
+
+func filledValue(value string) bool {
+	return len(value) != 0 && !strings.EqualFold(value, "unknown") && !strings.Contains(strings.ToLower(value), "{{cn")
+}
+
+// The score and the breakdown of every section
+func (completeness Completeness) String() string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Completeness: %d/100", completeness.Score))
+	if completeness.Stub {
+		output.WriteString(fmt.Sprintf(" (stub: %s)", strings.Join(completeness.Reasons, ", ")))
+	}
+	output.WriteString("\n")
+
+	for _, section := range completeness.Sections {
+		output.WriteString(fmt.Sprintf("  %-24s %3d%% (%d/%d)\n", section.Name, section.Score, section.Filled, section.Total))
+	}
+	return output.String()
+}
+
+// Writes the score to output/<appid>.completeness.json
+func (completeness Completeness) Write(gameId string) error {
+	data, err := json.MarshalIndent(completeness, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fmt.Sprintf("output/%s.completeness.json", gameId), data, 0777)
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func sectionScore(completeness Completeness, name string) (SectionScore, bool) {
+	for _, section := range completeness.Sections {
+		if section.Name == name {
+			return section, true
+		}
+	}
+	return SectionScore{}, false
+}
+
+// The article with every empty or unknown value of the templates given filled
+// by hand
+func fillTemplates(t *testing.T, article string, names ...string) string {
+	var edits []WikiEdit
+	templates := ParseTemplates(article)
+	for i := range templates {
+		if !containsFold(names, templates[i].Name) {
+			continue
+		}
+		for p := range templates[i].Params {
+			param := &templates[i].Params[p]
+			if !filledValue(param.Value) && !strings.HasSuffix(param.Name, "notes") {
+				edits = append(edits, param.Replace(article, "true"))
+			}
+		}
+	}
+
+	filled, err := ApplyEdits(article, edits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filled
+}
+
+func TestScoreGeneratedArticles(t *testing.T) {
+	config := DefaultConfig().Completeness
+	scores := make(map[string]Completeness)
+	for name, article := range generatedArticles() {
+		scores[name] = ScoreArticle(article, config)
+	}
+
+	sparse, typical, rich := scores["sparse"], scores["typical"], scores["rich"]
+	if !(sparse.Score < typical.Score && typical.Score < rich.Score) {
+		t.Errorf("scores: sparse %d, typical %d, rich %d, want them increasing", sparse.Score, typical.Score, rich.Score)
+	}
+
+	if want := []string{"the article is below 38", "Infobox is below 50"}; strings.Join(sparse.Reasons, ", ") != strings.Join(want, ", ") {
+		t.Errorf("the sparse article has reasons %q, want %q", sparse.Reasons, want)
+	}
+	for _, name := range []string{"typical", "rich"} {
+		if scores[name].Stub {
+			t.Errorf("the %s article is a stub (%s)", name, strings.Join(scores[name].Reasons, ", "))
+		}
+	}
+
+	// Left for a human to fill
+	for name, completeness := range scores {
+		if video, ok := sectionScore(completeness, "Video"); !ok || video.Score != 0 || video.Total == 0 {
+			t.Errorf("%s Video = %+v, want an empty section", name, video)
+		}
+		if languages, _ := sectionScore(completeness, "Languages"); languages.Score != 100 {
+			t.Errorf("%s Languages = %+v, want every language filled", name, languages)
+		}
+	}
+}
+
+func TestScoreWellFilledArticle(t *testing.T) {
+	generated := generatedArticles()["typical"]
+	article := fillTemplates(t, generated, "Video", "Input", "Audio", "Network/Multiplayer", "Network/Connections", "API", "Middleware")
+
+	config := DefaultConfig().Completeness
+	config.StubBelow = 60
+	config.Sections = map[string]int{"Video": 50, "Input": 50}
+
+	before := ScoreArticle(generated, config)
+	after := ScoreArticle(article, config)
+	if !before.Stub || len(before.Reasons) != 3 {
+		t.Errorf("the generated article has reasons %q, want the score, Video and Input", before.Reasons)
+	}
+	if after.Stub || after.Score <= before.Score {
+		t.Errorf("the filled article scores %d (stub: %q), the generated one %d", after.Score, after.Reasons, before.Score)
+	}
+}
+
+func TestScoreSparseArticle(t *testing.T) {
+	article := `{{Infobox game
+|developers   =
+{{Infobox game/row/developer|}}
+|release dates=
+{{Infobox game/row/date|Windows|unknown}}
+}}
+{{Video
+|widescreen resolution = unknown
+|vsync                 =
+|fov                   = {{cn|Not listed on Steam}}
+}}`
+
+	completeness := ScoreArticle(article, DefaultConfig().Completeness)
+	if !completeness.Stub || completeness.Score != 16 {
+		t.Errorf("completeness = %+v, want a stub scoring 16", completeness)
+	}
+	if len(completeness.Sections) != 2 {
+		t.Errorf("sections = %+v, want only the infobox and video, the article has no other", completeness.Sections)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+const CONFIG_FILE = "config.json"
+
+var AppConfig = DefaultConfig()
+
+func DefaultConfig() Config {
+	return Config{
+		ITAD: ITADConfig{
+			BaseURL: "https://api.isthereanydeal.com",
+			Country: "US",
+		},
+		GOG: GOGConfig{
+			Enabled:    true,
+			CatalogURL: "https://catalog.gog.com",
+			APIURL:     "https://api.gog.com",
+		},
+		Wikidata: WikidataConfig{
+			Enabled:  true,
+			Endpoint: "https://query.wikidata.org/sparql",
+		},
+		IGDB: IGDBConfig{
+			BaseURL:  "https://api.igdb.com/v4",
+			TokenURL: "https://id.twitch.tv/oauth2/token",
+		},
+		PCGW: PCGWConfig{
+			APIURL: "https://www.pcgamingwiki.com/w/api.php",
+		},
+		Companies: CompaniesConfig{
+			Enabled:     true,
+			AliasesFile: "company_aliases.json",
+		},
+		Subscriptions: "subscriptions.json",
+		StoresFile:    "stores.json",
+		SchemaFile:    "schema.json",
+		// Generated articles score from around 35 (a game with little more
+		// than a name on Steam) to around 45 (full Steam and external data).
+		// The infobox tells them apart best, it is mostly empty for the former
+		Completeness: CompletenessConfig{
+			StubBelow: 38,
+			Sections:  map[string]int{"Infobox": 50},
+		},
+	}
+}
+
+// Reads the config file placed next to the executable, any missing value
+// keeps its default so the file only has to contain what the user changes
+func LoadConfig(fileName string) (config Config, err error) {
+	config = DefaultConfig()
+
+	data, err := os.ReadFile(fileName)
+	if os.IsNotExist(err) {
+		return config, nil
+	} else if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(data, &config); err != nil {
+		err = fmt.Errorf("failed to parse '%s' (%s)", fileName, err)
+	}
+	return
+}
@@ -6,6 +6,44 @@ const (
 	API_LINK = "https://store.steampowered.com/api/appdetails?appids="
 	LOCALE   = "&l=english"
 	GH_LINK  = "https://github.com/phyziyx/steam2pcgw"
+
+	COVER_LINK  = "https://cdn.cloudflare.steamstatic.com/steam/apps/%s/library_600x900_2x.jpg"
+	STORE_LINK  = "https://store.steampowered.com/app/%s"
+	SEARCH_LINK = "https://store.steampowered.com/api/storesearch/?l=english&cc=US&term="
+	ITAD_LINK   = "https://isthereanydeal.com"
+
+	IGDB_LINK            = "https://www.igdb.com/games/%s"
+	WIKIDATA_LINK        = "https://www.wikidata.org/wiki/%s"
+	WIKIDATA_SEARCH_LINK = "https://www.wikidata.org/w/index.php?search=haswbstatement%%3AP1733%%3D%s"
+
+	IGDB_SOURCE_STEAM = 1 // The external_game_source of Steam app IDs on IGDB
+)
+
+const (
+	DRM_PROTECTION = "protection" // Goes into the DRM column of every row
+	DRM_LAUNCHER   = "launcher"   // Goes into the DRM column of every row
+	DRM_ACCOUNT    = "account"
+	DRM_ONLINE     = "online"
+)
+
+type LogLevel int
+
+const (
+	LOG_DEBUG LogLevel = iota
+	LOG_INFO
+	LOG_WARN
+	LOG_ERROR
+)
+
+// Sources of the auto-filled values, also used as the names of their references
+const (
+	SOURCE_STEAM_API     = "steam-appdetails"
+	SOURCE_STEAM_STORE   = "steam-store"
+	SOURCE_ITAD          = "isthereanydeal"
+	SOURCE_SUBSCRIPTIONS = "subscriptions"
+	SOURCE_WIKIDATA      = "wikidata"
+	SOURCE_IGDB          = "igdb"
+	SOURCE_GOG           = "gog"
 )
 
 type GenreId int
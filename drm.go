@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DRM names used by IsThereAnyDeal which differ from the PCGW ones
+var itadDRMNames = map[string]string{
+	"DRM Free":            "DRM-free",
+	"Epic Games Launcher": "Epic Games Store",
+	"Origin":              "EA app",
+	"Uplay":               "Ubisoft Connect",
+}
+
+var drmSignals = []DRMSignal{
+	{Key: "Denuvo", Kind: DRM_PROTECTION, Pattern: `(?i)\bdenuvo\b`},
+	{Key: "VMProtect", Kind: DRM_PROTECTION, Pattern: `(?i)\bvmprotect\b`},
+	{Key: "Arxan", Kind: DRM_PROTECTION, Pattern: `(?i)\b(arxan|guardit)\b`},
+	{Key: "StarForce", Kind: DRM_PROTECTION, Pattern: `(?i)\bstar ?force\b`},
+	{Key: "SecuROM", Kind: DRM_PROTECTION, Pattern: `(?i)\bsecurom\b`},
+	{Key: "EA app", Kind: DRM_LAUNCHER, Pattern: `(?i)\b(requires? (an )?(ea|origin) account|ea app|origin client)\b`},
+	{Key: "Ubisoft Connect", Kind: DRM_LAUNCHER, Pattern: `(?i)\b(ubisoft connect|ubisoft account|uplay)\b`},
+	{Key: "Rockstar Games Launcher", Kind: DRM_LAUNCHER, Pattern: `(?i)\b(rockstar games launcher|rockstar games social club|social club account)\b`},
+	{Key: "Battle.net", Kind: DRM_LAUNCHER, Pattern: `(?i)(\bbattle\.net\b|\bblizzard account\b)`},
+	{Key: "2K account", Kind: DRM_ACCOUNT, Pattern: `(?i)\b2k (account|games account)\b`},
+	{Key: "Xbox account", Kind: DRM_ACCOUNT, Pattern: `(?i)\b(xbox live|xbox account|microsoft account)\b`},
+	{Key: "Always online", Kind: DRM_ONLINE, Pattern: `(?i)\b(always[- ]online|persistent internet connection|internet connection (is )?required|requires? an? (active |constant )?internet connection)\b`},
+}
+
+// Classifies the DRM and launchers found in the Steam notices, the additional
+// notes of the system requirements are only scanned for copy protection as
+// they often mention publishers and accounts for other reasons. Notices which
+// did not match anything are returned so they can still be output as they are
+func (game *Game) ClassifyDRM() (signals []DRMSignal, unclassified []string) {
+	const notices = 2
+	sources := []string{game.Data.DRMNotice, game.Data.ExternalAccountNotice}
+	notesRe := regexp.MustCompile(`Additional Notes:([^\n]+)`)
+	for _, requirements := range []Requirement{game.Data.PCRequirements, game.Data.MACRequirements, game.Data.LinuxRequirements} {
+		for _, level := range []string{"minimum", "recommended"} {
+			if text, ok := requirements[level].(string); ok {
+				if notes := notesRe.FindStringSubmatch(RemoveTags(text, "\n")); len(notes) > 1 {
+					sources = append(sources, notes[1])
+				}
+			}
+		}
+	}
+
+	for i, source := range sources {
+		matched := false
+		for _, signal := range drmSignals {
+			if i >= notices && signal.Kind != DRM_PROTECTION {
+				continue
+			}
+			if !regexp.MustCompile(signal.Pattern).MatchString(source) {
+				continue
+			}
+
+			matched = true
+			found := false
+			for _, v := range signals {
+				if v.Key == signal.Key {
+					found = true
+					break
+				}
+			}
+			if !found {
+				signals = append(signals, signal)
+			}
+		}
+
+		// Only the notices are kept, requirement notes are mostly unrelated
+		if !matched && i < notices && len(strings.TrimSpace(source)) != 0 {
+			unclassified = append(unclassified, strings.TrimSpace(source))
+		}
+	}
+	return
+}
+
+// Extra DRM found in the Steam notices, it applies to the Steam version and
+// usually to every version sold as a Steam key
+func (game *Game) detectDRM() (keys []string) {
+	signals, _ := game.ClassifyDRM()
+	for _, signal := range signals {
+		if signal.Kind == DRM_PROTECTION || signal.Kind == DRM_LAUNCHER {
+			keys = append(keys, signal.Key)
+		}
+	}
+	return
+}
+
+// The {{ii}} lines written below the Availability table, they only speak for
+// all versions if none of the rows is DRM-free
+func (game *Game) DRMNotes() (output string) {
+	subject, use, require := "All versions", "use", "require"
+	if game.hasDRMFreeRow() {
+		subject, use, require = "The Steam version", "uses", "requires"
+	}
+
+	signals, unclassified := game.ClassifyDRM()
+	for _, signal := range signals {
+		switch signal.Kind {
+		case DRM_PROTECTION:
+			output += fmt.Sprintf("\n{{ii}} %s %s {{DRM|%s}}.", subject, use, signal.Key)
+		case DRM_LAUNCHER:
+			output += fmt.Sprintf("\n{{ii}} %s %s {{DRM|%s}}.", subject, require, signal.Key)
+		case DRM_ACCOUNT:
+			output += fmt.Sprintf("\n{{ii}} %s %s a %s.", subject, require, signal.Key)
+		case DRM_ONLINE:
+			output += fmt.Sprintf("\n{{ii}} %s %s an always-online connection.", subject, require)
+		}
+	}
+
+	for _, notice := range unclassified {
+		output += fmt.Sprintf("\n{{ii}} %s %s %s.", subject, require, strings.TrimSuffix(notice, "."))
+	}
+	return
+}
+
+// Whether any Availability row resolves to DRM-free, by the DRM IsThereAnyDeal
+// lists or else by the store default
+func (game *Game) hasDRMFreeRow() bool {
+	for name, store := range game.Data.Stores {
+		keys := storeDRMKeys(store)
+		if len(keys) == 0 {
+			valid, _ := FindStoreByDisplayName(name)
+			keys = []string{valid.DRM}
+		}
+		if containsString(keys, "DRM-free") {
+			return true
+		}
+	}
+	return false
+}
+
+// Account and online requirements, added to the notes of the Steam row
+func (game *Game) AvailabilityNotes() string {
+	var notes []string
+	signals, _ := game.ClassifyDRM()
+	for _, signal := range signals {
+		if signal.Kind == DRM_ACCOUNT {
+			notes = append(notes, fmt.Sprintf("Requires a %s.", signal.Key))
+		} else if signal.Kind == DRM_ONLINE {
+			notes = append(notes, "Requires an always-online connection.")
+		}
+	}
+	return strings.Join(notes, " ")
+}
+
+func (game *Game) SteamDRM() string {
+	return strings.Join(appendUnique([]string{"Steam"}, game.detectDRM()...), ", ")
+}
+
+// Resolves the DRM column of a third-party Availability row by combining the
+// store defaults, the DRM reported by IsThereAnyDeal and the Steam notices
+func (game *Game) ResolveDRM(name string, store Store) string {
+	valid, _ := FindStoreByDisplayName(name)
+	extras := game.detectDRM()
+
+	keys := storeDRMKeys(store)
+	if len(keys) != 0 && len(valid.DRM) != 0 && !containsString(keys, valid.DRM) {
+		game.addDRMConflict(fmt.Sprintf("%s usually uses %s, but IsThereAnyDeal lists %s", name, valid.DRM, strings.Join(keys, ", ")))
+	}
+
+	if len(keys) == 0 {
+		if len(valid.DRM) == 0 {
+			game.addDRMConflict(fmt.Sprintf("The DRM used by %s is unknown", name))
+			return "unknown"
+		}
+		keys = []string{valid.DRM}
+	}
+
+	if containsString(keys, "DRM-free") {
+		if len(extras) != 0 {
+			game.addDRMConflict(fmt.Sprintf("%s is listed as DRM-free, but the Steam version requires %s", name, strings.Join(extras, ", ")))
+		}
+		return "DRM-free"
+	}
+
+	return strings.Join(appendUnique(keys, extras...), ", ")
+}
+
+// The DRM IsThereAnyDeal lists for a store, by their PCGW names
+func storeDRMKeys(store Store) (keys []string) {
+	for _, drm := range store.DRM {
+		if alias, ok := itadDRMNames[drm]; ok {
+			drm = alias
+		}
+		keys = appendUnique(keys, drm)
+	}
+	return
+}
+
+func (game *Game) addDRMConflict(note string) {
+	if !containsString(game.Data.DRMConflicts, note) {
+		game.Data.DRMConflicts = append(game.Data.DRMConflicts, note)
+	}
+}
+
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		if !containsString(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+func (game *Game) SortedStores() []string {
+	names := make([]string, 0, len(game.Data.Stores))
+	for name := range game.Data.Stores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveDRM(t *testing.T) {
+	tests := []struct {
+		store     string
+		drm       []string
+		want      string
+		conflicts int
+	}{
+		// The display name differs from all of its scrape names
+		{store: "GamesPlanet", want: "Steam"},
+		{store: "GOG.com", drm: []string{"DRM Free"}, want: "DRM-free"},
+		{store: "Epic Games Store", drm: []string{"Steam"}, want: "Steam", conflicts: 1},
+		{store: "Not A Store", want: "unknown", conflicts: 1},
+	}
+
+	for _, test := range tests {
+		game := newTestGame("Test")
+		if got := game.ResolveDRM(test.store, Store{DRM: test.drm}); got != test.want {
+			t.Errorf("ResolveDRM(%q, %v) = %q, want %q", test.store, test.drm, got, test.want)
+		}
+		if len(game.Data.DRMConflicts) != test.conflicts {
+			t.Errorf("ResolveDRM(%q, %v) conflicts = %v, want %d", test.store, test.drm, game.Data.DRMConflicts, test.conflicts)
+		}
+	}
+}
+
+func TestClassifyDRM(t *testing.T) {
+	tests := []struct {
+		name               string
+		drmNotice, account string
+		notes              string // Additional notes of the minimum requirements
+		want, unclassified string
+	}{
+		{name: "protection notice", drmNotice: "Denuvo Anti-tamper", want: "Denuvo"},
+		{name: "EA account", account: "Requires an EA account", want: "EA app"},
+		{name: "Ubisoft Connect", account: "Ubisoft Connect account", want: "Ubisoft Connect"},
+		{name: "Social Club", account: "Rockstar Games Social Club", want: "Rockstar Games Launcher"},
+		{name: "two signals", drmNotice: "Denuvo Anti-tamper", account: "Requires a 2K account", want: "Denuvo, 2K account"},
+		{name: "unmatched notice", drmNotice: "The origin of every protection", unclassified: "The origin of every protection"},
+		{name: "protection in the notes", notes: "Uses Denuvo Anti-Tamper.", want: "Denuvo"},
+		{name: "origin story", notes: "An origin story told over five chapters."},
+		{name: "publisher", notes: "Published by Ubisoft."},
+		{name: "account in the notes", notes: "Requires an EA account to play online."},
+		{name: "online in the notes", notes: "Internet connection required for activation."},
+	}
+
+	for _, test := range tests {
+		game := newTestGame("Test")
+		game.Data.DRMNotice = test.drmNotice
+		game.Data.ExternalAccountNotice = test.account
+		if len(test.notes) != 0 {
+			game.Data.PCRequirements = Requirement{"minimum": "<strong>Minimum:</strong><br><ul><li><strong>Additional Notes:</strong> " + test.notes + "</li></ul>"}
+		}
+
+		signals, unclassified := game.ClassifyDRM()
+		var keys []string
+		for _, signal := range signals {
+			keys = append(keys, signal.Key)
+		}
+		if got := strings.Join(keys, ", "); got != test.want {
+			t.Errorf("%s: signals = %q, want %q", test.name, got, test.want)
+		}
+		if got := strings.Join(unclassified, ", "); got != test.unclassified {
+			t.Errorf("%s: unclassified = %q, want %q", test.name, got, test.unclassified)
+		}
+	}
+}
+
+func TestDRMNotes(t *testing.T) {
+	tests := []struct {
+		name   string
+		stores map[string]Store
+		want   string
+	}{
+		{"Steam keys only", map[string]Store{"Fanatical": {}}, "\n{{ii}} All versions use {{DRM|Denuvo}}.\n{{ii}} All versions require a 2K account."},
+		{"DRM-free listed", map[string]Store{"Fanatical": {}, "GOG.com": {DRM: []string{"DRM Free"}}}, "\n{{ii}} The Steam version uses {{DRM|Denuvo}}.\n{{ii}} The Steam version requires a 2K account."},
+		{"DRM-free by default", map[string]Store{"Zoom Platform": {}}, "\n{{ii}} The Steam version uses {{DRM|Denuvo}}.\n{{ii}} The Steam version requires a 2K account."},
+	}
+
+	for _, test := range tests {
+		game := newTestGame("Test")
+		game.Data.DRMNotice = "Denuvo Anti-tamper"
+		game.Data.ExternalAccountNotice = "Requires a 2K account"
+		game.Data.Stores = test.stores
+
+		if got := game.DRMNotes(); got != test.want {
+			t.Errorf("%s: DRMNotes() = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
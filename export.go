@@ -0,0 +1,162 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+var xmlTextReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// Generates the articles of the given app IDs, along with their redirects, and
+// writes them as a MediaWiki XML dump for Special:Import
+func RunExport(args []string) {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	fileName := flags.String("o", "output/export.xml", "file the dump is written to")
+	username := flags.String("user", "Steam2PCGW", "contributor of the revisions")
+	force := flags.Bool("force", false, "add the redirects without checking that no page has their title")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: export [-o file] [-user name] [-force] <appid>...")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return
+	}
+
+	dump := NewXMLDump()
+	client := NewPCGWClient(AppConfig.PCGW)
+	var scores []Completeness
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+
+	for _, input := range flags.Args() {
+		gameId, err := ResolveAppID(input, false)
+		if err != nil {
+			Log.Errorf("Skipping %s... (%s)", input, err)
+			continue
+		}
+
+		game, err := LoadGame(gameId)
+		if err != nil {
+			Log.Errorf("Skipping %s... (%s)", gameId, err)
+			continue
+		}
+
+		title := game.Title()
+		dump.Add(title.Title, GenerateArticle(gameId, &game), "", timestamp, *username)
+
+		scores = append(scores, *game.Data.Completeness)
+		if err = game.Data.Completeness.Write(gameId); err != nil {
+			Log.Warnf("Failed to write the completeness score... (%s)", err)
+		}
+
+		redirects := newRedirectPages(client, title, *force)
+		names := make([]string, 0, len(redirects))
+		for name := range redirects {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			dump.Add(name, redirects[name], title.Title, timestamp, *username)
+		}
+		Log.Infof("Added '%s' and %d redirect(s)", title.Title, len(names))
+	}
+
+	Log.SetField("app", "")
+
+	if len(dump.Pages) == 0 {
+		Log.Errorf("No article was generated, nothing to export...")
+		return
+	}
+
+	data, err := xml.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		Log.Errorf("Failed to encode the dump... (%s)", err)
+		return
+	}
+
+	if err = os.WriteFile(*fileName, append([]byte(xml.Header), append(data, '\n')...), 0777); err != nil {
+		Log.Errorf("Failed to write the dump... (%s)", err)
+		return
+	}
+	Log.Infof("Exported %d page(s) to %s", len(dump.Pages), *fileName)
+
+	// Articles needing the most work first
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Score < scores[j].Score
+	})
+	fmt.Println("Completeness of the articles (see output/<appid>.completeness.json for each section):")
+	for _, score := range scores {
+		fmt.Printf("  %3d/100 %s\n", score.Score, score.Title)
+	}
+}
+
+// The redirect pages of the title, leaving out those whose title is already a
+// page on PCGW unless force is set. Importing them would add a revision
+// replacing that page
+func newRedirectPages(client *PCGWClient, title PageTitle, force bool) map[string]string {
+	pages := title.RedirectPages()
+	if force {
+		return pages
+	}
+
+	for name := range pages {
+		existing, err := client.FindByTitle(name)
+		if err != nil {
+			Log.Warnf("Leaving out the redirect '%s', failed to check whether the page exists... (%s)", name, err)
+			delete(pages, name)
+		} else if len(existing) != 0 {
+			Log.Warnf("Leaving out the redirect '%s', the page exists (as '%s')", name, existing)
+			delete(pages, name)
+		}
+	}
+	return pages
+}
+
+func NewXMLDump() *XMLDump {
+	return &XMLDump{
+		Xmlns:   "http://www.mediawiki.org/xml/export-0.11/",
+		Version: "0.11",
+		Lang:    "en",
+	}
+}
+
+// Adds a main namespace page, redirects are pages whose redirect title is set
+func (dump *XMLDump) Add(title, text, redirect, timestamp, username string) {
+	page := XMLPage{
+		Title: title,
+		Revision: XMLRevision{
+			Timestamp:   timestamp,
+			Contributor: XMLContributor{Username: username},
+			Comment:     editSummary(),
+			Model:       "wikitext",
+			Format:      "text/x-wiki",
+			Text:        XMLText{Space: "preserve", Bytes: len(text), Text: xmlTextReplacer.Replace(text)},
+			SHA1:        revisionSHA1(text),
+		},
+	}
+
+	if len(redirect) != 0 {
+		page.Redirect = &XMLRedirect{Title: redirect}
+	}
+	dump.Pages = append(dump.Pages, page)
+}
+
+// SHA-1 of the text in base 36, padded to 31 characters like MediaWiki
+func revisionSHA1(text string) string {
+	sum := sha1.Sum([]byte(text))
+	hash := new(big.Int).SetBytes(sum[:]).Text(36)
+	return strings.Repeat("0", 31-len(hash)) + hash
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewRedirectPages(t *testing.T) {
+	standIn := newPCGWStandIn(t, "Witcher 3: Wild Hunt")
+	client := NewPCGWClient(PCGWConfig{APIURL: standIn.URL})
+	title := ArticleTitle("The Witcher® 3: Wild Hunt")
+
+	pages := newRedirectPages(client, title, false)
+	if want := map[string]string{"The Witcher® 3: Wild Hunt": "#REDIRECT [[The Witcher 3: Wild Hunt]]\n"}; !reflect.DeepEqual(pages, want) {
+		t.Errorf("redirects = %q, want the existing page left out", pages)
+	}
+
+	if pages = newRedirectPages(client, title, true); len(pages) != 2 {
+		t.Errorf("redirects with force = %q, want both", pages)
+	}
+
+	// Pages which could not be checked are left out too
+	standIn.Close()
+	if pages = newRedirectPages(client, title, false); len(pages) != 0 {
+		t.Errorf("redirects without PCGW = %q, want none", pages)
+	}
+}
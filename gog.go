@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type GOGClient struct {
+	CatalogURL string
+	APIURL     string
+	Client     *http.Client
+}
+
+func NewGOGClient(config GOGConfig) *GOGClient {
+	return &GOGClient{
+		CatalogURL: strings.TrimSuffix(config.CatalogURL, "/"),
+		APIURL:     strings.TrimSuffix(config.APIURL, "/"),
+		Client:     &http.Client{},
+	}
+}
+
+func (client *GOGClient) get(link string, result interface{}) error {
+	response, err := client.Client.Get(link)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("'%s' returned HTTP code %d", link, response.StatusCode)
+	}
+
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+func (client *GOGClient) Search(title string) ([]GOGCatalogProduct, error) {
+	query := url.Values{
+		"query":       {"like:" + title},
+		"productType": {"in:game,pack"},
+		"limit":       {"20"},
+	}
+
+	var catalog GOGCatalog
+	err := client.get(client.CatalogURL+"/v1/catalog?"+query.Encode(), &catalog)
+	return catalog.Products, err
+}
+
+func (client *GOGClient) Product(id string) (product GOGProduct, err error) {
+	err = client.get(client.APIURL+"/products/"+url.PathEscape(id)+"?expand=expanded_dlcs", &product)
+	return
+}
+
+// Lowercased title without symbols, so that "Portal™ 2" matches "Portal 2"
+func normaliseTitle(title string) string {
+	title = strings.ToLower(SanitiseName(title, true))
+	return strings.Join(regexp.MustCompile(`[^a-z0-9]+`).Split(title, -1), "")
+}
+
+func developersMatch(steam []string, gog []string) bool {
+	// Nothing to verify against
+	if len(steam) == 0 || len(gog) == 0 {
+		return true
+	}
+
+	for _, a := range steam {
+		for _, b := range gog {
+			x, y := normaliseTitle(SanitiseName(a, false)), normaliseTitle(SanitiseName(b, false))
+			if len(x) != 0 && len(y) != 0 && (strings.Contains(x, y) || strings.Contains(y, x)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Products named after the game with an edition suffix, or bundles whose name
+// starts with it
+func isGOGEdition(product GOGCatalogProduct, title string) bool {
+	if normaliseTitle(editionRegex.ReplaceAllString(product.Title, "")) == title {
+		return true
+	}
+	return product.ProductType == "pack" && strings.HasPrefix(normaliseTitle(product.Title), title)
+}
+
+// Finds the game on the GOG.com catalogue, the match is only used if both the
+// title and one of the developers match
+func (game *Game) fetchGOG(client *GOGClient) error {
+	products, err := client.Search(SanitiseName(game.Data.Name, true))
+	if err != nil {
+		return err
+	}
+
+	title := normaliseTitle(game.Data.Name)
+	var match *GOGCatalogProduct
+	for i, product := range products {
+		if normaliseTitle(product.Title) != title {
+			continue
+		}
+
+		if !developersMatch(game.Data.Developers, product.Developers) {
+			Log.Infof("Found '%s' on GOG.com, but its developers (%s) do not match, skipping it...", product.Title, strings.Join(product.Developers, ", "))
+			continue
+		}
+
+		match = &products[i]
+		break
+	}
+
+	if match == nil {
+		return errors.New("no matching product found")
+	}
+
+	product, err := client.Product(match.ID)
+	if err != nil {
+		return err
+	}
+
+	game.Data.GogID = strconv.FormatInt(product.ID, 10)
+	game.Data.GogSideIDs = nil
+	for _, dlc := range product.DLCs.Products {
+		game.Data.GogSideIDs = append(game.Data.GogSideIDs, strconv.FormatInt(dlc.ID, 10))
+	}
+
+	// Editions and bundles of the game are sold as products of their own
+	for _, edition := range products {
+		if edition.ID != match.ID && isGOGEdition(edition, title) && developersMatch(game.Data.Developers, edition.Developers) {
+			game.Data.GogSideIDs = appendUnique(game.Data.GogSideIDs, edition.ID)
+		}
+	}
+
+	var platforms []string
+	if product.ContentSystemCompatibility.Windows {
+		platforms = append(platforms, "Windows")
+	}
+	if product.ContentSystemCompatibility.OSX {
+		platforms = append(platforms, "OS X")
+	}
+	if product.ContentSystemCompatibility.Linux {
+		platforms = append(platforms, "Linux")
+	}
+
+	// Replaces whatever IsThereAnyDeal found, GOG.com knows better
+	game.Data.Stores["GOG.com"] = Store{
+		Platforms: strings.Join(platforms, ", "),
+		URL:       product.Slug,
+		DRM:       []string{"DRM-free"},
+	}
+
+	Log.Infof("Found the game on GOG.com (ID: %s)", game.Data.GogID)
+	return nil
+}
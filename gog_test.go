@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Stand-in for the GOG.com catalogue and product API
+func newGOGStandIn(t *testing.T, products []GOGCatalogProduct) *GOGClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/catalog":
+			if !strings.HasPrefix(r.URL.Query().Get("query"), "like:") {
+				http.Error(w, "bad query", http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(GOGCatalog{Products: products})
+		case r.URL.Path == "/products/1207664643":
+			var product GOGProduct
+			product.ID = 1207664643
+			product.Slug = "the_witcher_3_wild_hunt"
+			product.ContentSystemCompatibility.Windows = true
+			product.DLCs.Products = append(product.DLCs.Products, struct {
+				ID int64 `json:"id"`
+			}{ID: 1207664663})
+			json.NewEncoder(w).Encode(product)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return NewGOGClient(GOGConfig{CatalogURL: server.URL, APIURL: server.URL})
+}
+
+func TestFetchGOG(t *testing.T) {
+	client := newGOGStandIn(t, []GOGCatalogProduct{
+		{ID: "1", Title: "The Witcher 3: Wild Hunt", ProductType: "game", Developers: []string{"Another Studio"}},
+		{ID: "1207664643", Title: "The Witcher® 3: Wild Hunt", ProductType: "game", Developers: []string{"CD PROJEKT RED"}},
+		{ID: "1495134320", Title: "The Witcher 3: Wild Hunt - Game of the Year Edition", ProductType: "game", Developers: []string{"CD PROJEKT RED"}},
+		{ID: "1640424747", Title: "The Witcher 3: Wild Hunt Complete Bundle", ProductType: "pack", Developers: []string{"CD PROJEKT RED"}},
+		{ID: "2", Title: "The Witcher: Enhanced Edition", ProductType: "game", Developers: []string{"CD PROJEKT RED"}},
+	})
+
+	game := newTestGame("The Witcher® 3: Wild Hunt")
+	game.Data.Developers = []string{"CD PROJEKT RED"}
+	if err := game.fetchGOG(client); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first product has the title but not the developer
+	if game.Data.GogID != "1207664643" {
+		t.Errorf("GogID = %q, want 1207664643", game.Data.GogID)
+	}
+
+	sideIDs := append([]string{}, game.Data.GogSideIDs...)
+	sort.Strings(sideIDs)
+	if want := "1207664663,1495134320,1640424747"; strings.Join(sideIDs, ",") != want {
+		t.Errorf("GogSideIDs = %v, want the DLC, the edition and the bundle (%s)", game.Data.GogSideIDs, want)
+	}
+
+	if store := game.Data.Stores["GOG.com"]; store.URL != "the_witcher_3_wild_hunt" || store.Platforms != "Windows" {
+		t.Errorf("GOG.com row = %+v", store)
+	}
+}
+
+func TestFetchGOGNoMatch(t *testing.T) {
+	client := newGOGStandIn(t, []GOGCatalogProduct{
+		{ID: "1", Title: "The Witcher 3: Wild Hunt", Developers: []string{"Another Studio"}},
+		{ID: "2", Title: "The Witcher 2", Developers: []string{"CD PROJEKT RED"}},
+	})
+
+	game := newTestGame("The Witcher 3: Wild Hunt")
+	game.Data.Developers = []string{"CD PROJEKT RED"}
+	if err := game.fetchGOG(client); err == nil {
+		t.Fatal("fetchGOG matched a product with another title or developer")
+	}
+	if len(game.Data.GogID) != 0 || len(game.Data.Stores) != 0 {
+		t.Errorf("GogID = %q, stores = %v, want nothing set", game.Data.GogID, game.Data.Stores)
+	}
+}
+
+func TestIsGOGEdition(t *testing.T) {
+	tests := []struct {
+		product GOGCatalogProduct
+		want    bool
+	}{
+		{GOGCatalogProduct{Title: "Portal 2 - Deluxe Edition", ProductType: "game"}, true},
+		{GOGCatalogProduct{Title: "Portal 2 Complete Pack", ProductType: "pack"}, true},
+		{GOGCatalogProduct{Title: "Portal 2 Soundtrack", ProductType: "game"}, false},
+		{GOGCatalogProduct{Title: "Portal Bundle", ProductType: "pack"}, false},
+	}
+
+	for _, test := range tests {
+		if got := isGOGEdition(test.product, normaliseTitle("Portal 2")); got != test.want {
+			t.Errorf("isGOGEdition(%q) = %v, want %v", test.product.Title, got, test.want)
+		}
+	}
+}
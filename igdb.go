@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+type IGDBClient struct {
+	BaseURL      string
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	AccessToken  string
+	Client       *http.Client
+}
+
+func NewIGDBClient(config IGDBConfig) *IGDBClient {
+	return &IGDBClient{
+		BaseURL:      strings.TrimSuffix(config.BaseURL, "/"),
+		TokenURL:     config.TokenURL,
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		AccessToken:  config.AccessToken,
+		Client:       &http.Client{},
+	}
+}
+
+// IGDB uses Twitch credentials, the token is only requested if the config
+// does not already contain one
+func (client *IGDBClient) token() error {
+	if len(client.AccessToken) != 0 {
+		return nil
+	}
+
+	query := url.Values{
+		"client_id":     {client.ClientID},
+		"client_secret": {client.ClientSecret},
+		"grant_type":    {"client_credentials"},
+	}
+
+	response, err := client.Client.Post(client.TokenURL+"?"+query.Encode(), "application/x-www-form-urlencoded", nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("'%s' returned HTTP code %d", client.TokenURL, response.StatusCode)
+	}
+
+	var token IGDBToken
+	if err = json.NewDecoder(response.Body).Decode(&token); err != nil {
+		return err
+	}
+
+	client.AccessToken = token.AccessToken
+	return nil
+}
+
+// Looks up the game through its Steam external ID
+func (client *IGDBClient) FindBySteamID(appId string) (game IGDBGame, err error) {
+	if len(client.ClientID) == 0 {
+		err = errors.New("no IGDB client ID is set in the config")
+		return
+	}
+
+	if !regexp.MustCompile(`^\d+$`).MatchString(appId) {
+		err = errors.New("invalid Steam app ID")
+		return
+	}
+
+	if err = client.token(); err != nil {
+		return
+	}
+
+	// The category field is deprecated, external_game_source 1 is Steam
+	body := fmt.Sprintf(`fields game.slug, game.name, game.rating, game.aggregated_rating; where external_game_source = %d & uid = "%s";`, IGDB_SOURCE_STEAM, appId)
+	req, err := http.NewRequest("POST", client.BaseURL+"/external_games", strings.NewReader(body))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Client-ID", client.ClientID)
+	req.Header.Set("Authorization", "Bearer "+client.AccessToken)
+
+	response, err := client.Client.Do(req)
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err = fmt.Errorf("'%s' returned HTTP code %d", req.URL.Path, response.StatusCode)
+		return
+	}
+
+	var externalGames []IGDBExternalGame
+	if err = json.NewDecoder(response.Body).Decode(&externalGames); err != nil {
+		return
+	}
+
+	if len(externalGames) == 0 || len(externalGames[0].Game.Slug) == 0 {
+		err = errors.New("game not found on IGDB")
+		return
+	}
+
+	if len(externalGames) > 1 {
+		Log.Warnf("Found %d IGDB games for the app ID, using '%s'...", len(externalGames), externalGames[0].Game.Slug)
+	}
+	return externalGames[0].Game, nil
+}
+
+func (game *Game) fetchIGDB(client *IGDBClient, appId string) error {
+	result, err := client.FindBySteamID(appId)
+	if err != nil {
+		return err
+	}
+
+	rating := result.Rating
+	if rating == 0 {
+		rating = result.AggregatedRating
+	}
+
+	game.Data.IGDB = &IGDBRating{
+		Slug:  result.Slug,
+		Score: int(math.Round(rating)),
+	}
+	return nil
+}
+
+// The IGDB reception row, and the igdb field which is only set if there is no
+// rating to show in the row
+func (game *Game) IGDBReception() (row string, field string) {
+	if game.Data.IGDB != nil && game.Data.IGDB.Score != 0 && validateScore(game.Data.IGDB.Score) == nil {
+		return receptionRow("IGDB", game.Data.IGDB.Slug, game.Data.IGDB.Score), ""
+	}
+
+	if game.Data.IGDB != nil {
+		return "", game.Data.IGDB.Slug
+	} else if slug := game.Data.ExternalIDs["igdb"]; len(slug) != 0 {
+		return "", slug
+	}
+
+	return placeholderRow("IGDB"), ""
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Stand-in for the Twitch token endpoint and the IGDB external games
+// endpoint, which only knows Portal 2
+func newIGDBStandIn(t *testing.T, tokenRequests *int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth2/token":
+			*tokenRequests++
+			query := r.URL.Query()
+			if r.Method != "POST" || query.Get("client_id") != "id" || query.Get("client_secret") != "secret" || query.Get("grant_type") != "client_credentials" {
+				http.Error(w, "invalid client", http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(IGDBToken{AccessToken: "token", ExpiresIn: 3600})
+		case "/v4/external_games":
+			if r.Header.Get("Client-ID") != "id" || r.Header.Get("Authorization") != "Bearer token" {
+				http.Error(w, "unauthorised", http.StatusUnauthorized)
+				return
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			if strings.Contains(string(body), "category") || !strings.Contains(string(body), "external_game_source = 1") {
+				http.Error(w, "bad query", http.StatusBadRequest)
+				return
+			}
+
+			games := []IGDBExternalGame{}
+			if strings.Contains(string(body), `uid = "620"`) {
+				games = append(games, IGDBExternalGame{ID: 1, Game: IGDBGame{ID: 72, Name: "Portal 2", Slug: "portal-2", AggregatedRating: 94.6}})
+			}
+			json.NewEncoder(w).Encode(games)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchIGDB(t *testing.T) {
+	var tokenRequests int
+	server := newIGDBStandIn(t, &tokenRequests)
+	client := NewIGDBClient(IGDBConfig{ClientID: "id", ClientSecret: "secret", BaseURL: server.URL + "/v4/", TokenURL: server.URL + "/oauth2/token"})
+
+	game := newTestGame("Portal 2")
+	if err := game.fetchIGDB(client, "620"); err != nil {
+		t.Fatal(err)
+	}
+	if game.Data.IGDB == nil || game.Data.IGDB.Slug != "portal-2" || game.Data.IGDB.Score != 95 {
+		t.Errorf("IGDB = %+v, want portal-2 scored 95 from the aggregated rating", game.Data.IGDB)
+	}
+
+	// The token is requested once and reused
+	if err := game.fetchIGDB(client, "620"); err != nil {
+		t.Fatal(err)
+	}
+	if tokenRequests != 1 {
+		t.Errorf("the token was requested %d time(s), want 1", tokenRequests)
+	}
+}
+
+func TestFetchIGDBNoResult(t *testing.T) {
+	var tokenRequests int
+	server := newIGDBStandIn(t, &tokenRequests)
+	client := NewIGDBClient(IGDBConfig{ClientID: "id", AccessToken: "token", BaseURL: server.URL + "/v4", TokenURL: server.URL + "/oauth2/token"})
+
+	game := newTestGame("Unknown")
+	if err := game.fetchIGDB(client, "999"); err == nil {
+		t.Fatal("fetchIGDB found a game the stand-in does not have")
+	}
+	if game.Data.IGDB != nil {
+		t.Errorf("IGDB = %+v, want nothing set", game.Data.IGDB)
+	}
+	if tokenRequests != 0 {
+		t.Error("a token was requested although the config has one")
+	}
+}
+
+func TestIGDBTokenRejected(t *testing.T) {
+	var tokenRequests int
+	server := newIGDBStandIn(t, &tokenRequests)
+	client := NewIGDBClient(IGDBConfig{ClientID: "id", ClientSecret: "wrong", BaseURL: server.URL + "/v4", TokenURL: server.URL + "/oauth2/token"})
+
+	if _, err := client.FindBySteamID("620"); err == nil {
+		t.Fatal("FindBySteamID succeeded with rejected credentials")
+	}
+	if len(client.AccessToken) != 0 {
+		t.Errorf("AccessToken = %q, want none", client.AccessToken)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Links carrying the app ID: store pages, community hubs, SteamDB and the
+// steam:// protocol (steam://store/620, steam://run/620...)
+var appLinkRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)^(?:https?://)?(?:store\.steampowered\.com|steamcommunity\.com|(?:www\.)?steamdb\.info)/(?:app|games)/(\d+)(?:[/?#].*)?$`),
+	regexp.MustCompile(`(?i)^steam://(?:store|run|rungameid|install|nav/games/details|url/StoreAppPage|url/GameHub)/(\d+)/?$`),
+	regexp.MustCompile(`(?i)^steam://openurl/(.+)$`),
+}
+
+var appIdRegex = regexp.MustCompile(`^\d+$`)
+
+// Turns what the user gave (an app ID, a link or the name of the game) into
+// an app ID. Names are searched on the Steam store, with a list to pick from
+// if interactive, otherwise the best match is used
+func ResolveAppID(input string, interactive bool) (string, error) {
+	input = strings.TrimSpace(input)
+
+	if appIdRegex.MatchString(input) {
+		return validAppID(input)
+	}
+
+	for _, regex := range appLinkRegexes {
+		match := regex.FindStringSubmatch(input)
+		if match == nil {
+			continue
+		}
+
+		if appIdRegex.MatchString(match[1]) {
+			return validAppID(match[1])
+		}
+		// steam://openurl/ wraps a store link
+		return ResolveAppID(match[1], interactive)
+	}
+
+	if strings.Contains(input, "://") || strings.HasPrefix(strings.ToLower(input), "www.") {
+		return "", fmt.Errorf("'%s' is not a Steam store, community hub or SteamDB link to an app", input)
+	}
+
+	apps, err := SearchApps(input)
+	if err != nil {
+		return "", fmt.Errorf("failed to search the Steam store for '%s' (%s)", input, err)
+	}
+
+	if len(apps) == 0 {
+		return "", fmt.Errorf("no game named '%s' was found on the Steam store", input)
+	}
+
+	app := bestMatch(input, apps)
+	if len(apps) == 1 {
+		Log.Infof("Found '%s' (app ID %d)", app.Name, app.ID)
+	} else if interactive {
+		if app, err = chooseApp(apps); err != nil {
+			return "", err
+		}
+	} else {
+		Log.Warnf("Found %d games for '%s', using '%s' (app ID %d)", len(apps), input, app.Name, app.ID)
+	}
+	return strconv.FormatInt(app.ID, 10), nil
+}
+
+func validAppID(input string) (string, error) {
+	id, err := strconv.ParseInt(input, 10, 32)
+	if err != nil || id <= 0 {
+		return "", fmt.Errorf("'%s' is not a valid Steam app ID", input)
+	}
+	return strconv.FormatInt(id, 10), nil
+}
+
+// Apps (DLCs and other kinds are left out) found by the Steam store search
+func SearchApps(term string) (apps []SteamSearchItem, err error) {
+	response, err := makeRequest(SEARCH_LINK + url.QueryEscape(term))
+	if err = checkRequest(response, err); err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	var search SteamSearch
+	if err = json.NewDecoder(response.Body).Decode(&search); err != nil {
+		return
+	}
+
+	for _, item := range search.Items {
+		if item.Type == "app" {
+			apps = append(apps, item)
+		}
+	}
+	return
+}
+
+// The app named exactly like the search, otherwise the first one Steam found
+func bestMatch(term string, apps []SteamSearchItem) SteamSearchItem {
+	for _, app := range apps {
+		if normaliseTitle(app.Name) == normaliseTitle(term) {
+			return app
+		}
+	}
+	return apps[0]
+}
+
+// Lists the apps and asks which one was meant, until a listed one is picked
+// or the input cannot be read
+func chooseApp(apps []SteamSearchItem) (SteamSearchItem, error) {
+	for i, app := range apps {
+		fmt.Fprintf(os.Stderr, "%2d. %s (app ID %d)\n", i+1, app.Name, app.ID)
+	}
+
+	for {
+		fmt.Fprintf(os.Stderr, "Pick a game (1-%d): ", len(apps))
+		text, err := TakeInput()
+		if err == errEmptyInput {
+			Log.Warnf("No game was picked")
+			continue
+		} else if err != nil {
+			return SteamSearchItem{}, err
+		}
+
+		if choice, err := strconv.Atoi(strings.TrimSpace(text)); err == nil && choice >= 1 && choice <= len(apps) {
+			return apps[choice-1], nil
+		}
+		Log.Warnf("'%s' is not one of the games listed", text)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestResolveAppID(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"620", "620"},
+		{" 0620 ", "620"},
+		{"https://store.steampowered.com/app/620/Portal_2/", "620"},
+		{"store.steampowered.com/app/620", "620"},
+		{"http://store.steampowered.com/app/620?snr=1_7_7_151_150_1", "620"},
+		{"https://steamcommunity.com/app/620/discussions/", "620"},
+		{"https://steamcommunity.com/games/620/announcements", "620"},
+		{"https://steamdb.info/app/620/", "620"},
+		{"https://www.steamdb.info/app/620/charts/#graphs", "620"},
+		{"steam://run/620", "620"},
+		{"steam://store/620/", "620"},
+		{"steam://rungameid/620", "620"},
+		{"steam://openurl/https://store.steampowered.com/app/620/Portal_2/", "620"},
+		{"STEAM://RUN/620", "620"},
+		{"2147483647", "2147483647"},
+	}
+
+	for _, test := range tests {
+		if got, err := ResolveAppID(test.input, false); err != nil || got != test.want {
+			t.Errorf("ResolveAppID(%q) = %q, %v, want %q", test.input, got, err, test.want)
+		}
+	}
+}
+
+// None of these are searched on the Steam store
+func TestResolveAppIDInvalid(t *testing.T) {
+	for _, input := range []string{
+		"0",
+		"000",
+		"2147483648",
+		"99999999999999999999999",
+		"https://store.steampowered.com/app/0/",
+		"https://store.steampowered.com/app/99999999999/",
+		"steam://run/0",
+		"steam://openurl/https://example.com/app/620",
+		"https://example.com/app/620",
+		"www.example.com",
+		"steam://friends/add/620",
+	} {
+		if got, err := ResolveAppID(input, false); err == nil {
+			t.Errorf("ResolveAppID(%q) = %q, want an error", input, got)
+		}
+	}
+}
+
+func TestBestMatch(t *testing.T) {
+	apps := []SteamSearchItem{
+		{Type: "app", Name: "Portal 2 - The Final Hours", ID: 323180},
+		{Type: "app", Name: "Portal 2", ID: 620},
+		{Type: "app", Name: "Portal", ID: 400},
+		{Type: "app", Name: "The Witcher® 3: Wild Hunt", ID: 292030},
+	}
+
+	tests := []struct {
+		term string
+		want int64
+	}{
+		{"Portal 2", 620},
+		{"portal 2", 620},
+		{"PORTAL", 400},
+		{"the witcher 3 wild hunt", 292030},
+		{"Portal 3", 323180},
+	}
+
+	for _, test := range tests {
+		if got := bestMatch(test.term, apps); got.ID != test.want {
+			t.Errorf("bestMatch(%q) = %d, want %d", test.term, got.ID, test.want)
+		}
+	}
+}
+
+// Runs chooseApp with the given input, returning what was logged
+func runChooseApp(input string, apps []SteamSearchItem) (SteamSearchItem, string, error) {
+	var buffer bytes.Buffer
+	output, reader := Log.Output, stdinReader
+	Log.Output, stdinReader = &buffer, bufio.NewReader(strings.NewReader(input))
+	defer func() { Log.Output, stdinReader = output, reader }()
+
+	app, err := chooseApp(apps)
+	return app, buffer.String(), err
+}
+
+func TestChooseApp(t *testing.T) {
+	apps := []SteamSearchItem{{Name: "Portal", ID: 400}, {Name: "Portal 2", ID: 620}}
+
+	app, logged, err := runChooseApp("portal\n\n0\n3\n2\n", apps)
+	if err != nil || app.ID != 620 {
+		t.Fatalf("chooseApp = %+v, %v, want Portal 2", app, err)
+	}
+	for _, want := range []string{"'portal' is not one of the games listed", "No game was picked", "'0' is not", "'3' is not"} {
+		if !strings.Contains(logged, want) {
+			t.Errorf("the log does not contain %q:\n%s", want, logged)
+		}
+	}
+
+	// The input ends without a valid choice
+	if _, _, err = runChooseApp("x\n", apps); err != io.EOF {
+		t.Errorf("chooseApp at the end of the input returned %v, want io.EOF", err)
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ITADClient struct {
+	BaseURL string
+	SiteURL string // Scraped when the API cannot be used
+	Key     string
+	Country string
+	Client  *http.Client
+}
+
+func NewITADClient(config ITADConfig) *ITADClient {
+	return &ITADClient{
+		BaseURL: strings.TrimSuffix(config.BaseURL, "/"),
+		SiteURL: ITAD_LINK,
+		Key:     config.Key,
+		Country: config.Country,
+		Client:  &http.Client{},
+	}
+}
+
+func (client *ITADClient) endpoint(path string, query url.Values) string {
+	query.Set("key", client.Key)
+	return client.BaseURL + path + "?" + query.Encode()
+}
+
+func (client *ITADClient) do(req *http.Request, result interface{}) error {
+	response, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("'%s' returned HTTP code %d", req.URL.Path, response.StatusCode)
+	}
+
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+// Finds the ITAD game ID of a Steam app
+func (client *ITADClient) Lookup(appId string) (string, error) {
+	req, err := http.NewRequest("GET", client.endpoint("/games/lookup/v1", url.Values{"appid": {appId}}), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var lookup ITADLookup
+	if err = client.do(req, &lookup); err != nil {
+		return "", err
+	}
+
+	if !lookup.Found {
+		return "", errors.New("game not found on IsThereAnyDeal")
+	}
+	return lookup.Game.ID, nil
+}
+
+func (client *ITADClient) Reviews(id string) ([]ITADReview, error) {
+	req, err := http.NewRequest("GET", client.endpoint("/games/info/v2", url.Values{"id": {id}}), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var info ITADInfo
+	err = client.do(req, &info)
+	return info.Reviews, err
+}
+
+func (client *ITADClient) Deals(id string) ([]ITADDeal, error) {
+	body, _ := json.Marshal([]string{id})
+	req, err := http.NewRequest("POST", client.endpoint("/games/prices/v3", url.Values{"country": {client.Country}}), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var prices []ITADPrices
+	if err = client.do(req, &prices); err != nil {
+		return nil, err
+	}
+
+	for _, price := range prices {
+		if price.ID == id {
+			return price.Deals, nil
+		}
+	}
+	return nil, nil
+}
+
+// ITAD deal links go through a redirect, follow it to get the actual store page
+func (client *ITADClient) resolveLink(link string) string {
+	response, err := client.Client.Head(link)
+	if err != nil {
+		return link
+	}
+	response.Body.Close()
+	return response.Request.URL.String()
+}
+
+// Fetches the ratings and stores from the API, or scrapes the game page if the
+// API cannot be used
+func (game *Game) loadITAD(client *ITADClient, appId string) {
+	if err := game.fetchITAD(client, appId); err != nil {
+		Log.Warnf("Failed to fetch IsThereAnyDeal API data, scraping the page instead... (%s)", err)
+		game.scrapeITAD(client.PageURL(appId))
+	}
+
+	if len(game.Data.Stores) != 0 || len(game.Data.Ratings) != 0 {
+		game.addSource(SOURCE_ITAD, "IsThereAnyDeal", client.PageURL(appId), time.Now())
+	}
+}
+
+func (client *ITADClient) PageURL(appId string) string {
+	return fmt.Sprintf("%s/steam/app/%s", strings.TrimSuffix(client.SiteURL, "/"), appId)
+}
+
+func (game *Game) fetchITAD(client *ITADClient, appId string) error {
+	if len(client.Key) == 0 {
+		return errors.New("no IsThereAnyDeal API key is set in the config")
+	}
+
+	id, err := client.Lookup(appId)
+	if err != nil {
+		return err
+	}
+
+	reviews, err := client.Reviews(id)
+	if err != nil {
+		return err
+	}
+
+	for _, review := range reviews {
+		game.AddRating(review.Source, strconv.Itoa(review.Score), review.URL)
+	}
+
+	deals, err := client.Deals(id)
+	if err != nil {
+		return err
+	}
+
+	for _, deal := range deals {
+		var platforms []string
+		for _, platform := range deal.Platforms {
+			// Same abbreviations as the ones scraped from the ITAD page
+			platforms = append(platforms, strings.Replace(platform.Name, "Windows", "Win", 1))
+		}
+
+		var drm []string
+		for _, v := range deal.DRM {
+			drm = append(drm, v.Name)
+		}
+
+		// Only the links of stores written to the article are followed
+		link := deal.URL
+		if _, ok := FindStore(deal.Shop.Name); ok {
+			link = client.resolveLink(link)
+		}
+		game.AddStore(deal.Shop.Name, strings.Join(platforms, ", "), link, drm)
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Stand-in for the IsThereAnyDeal API and site, counting the requests made
+// to each path
+type itadStandIn struct {
+	*httptest.Server
+	mutex sync.Mutex
+	hits  map[string]int
+}
+
+func newITADStandIn(t *testing.T, failAPI bool) *itadStandIn {
+	standIn := &itadStandIn{hits: make(map[string]int)}
+	standIn.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		standIn.mutex.Lock()
+		standIn.hits[r.URL.Path]++
+		standIn.mutex.Unlock()
+
+		if failAPI && strings.HasPrefix(r.URL.Path, "/games/") {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		if strings.HasPrefix(r.URL.Path, "/games/") && r.URL.Query().Get("key") != "key" {
+			http.Error(w, "invalid key", http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/games/lookup/v1":
+			if r.URL.Query().Get("appid") != "620" {
+				json.NewEncoder(w).Encode(ITADLookup{})
+				return
+			}
+			var lookup ITADLookup
+			lookup.Found = true
+			lookup.Game.ID = "portal-2"
+			json.NewEncoder(w).Encode(lookup)
+		case "/games/info/v2":
+			json.NewEncoder(w).Encode(ITADInfo{Reviews: []ITADReview{
+				{Score: 95, Source: "Metascore", URL: "https://www.metacritic.com/game/portal-2/critic-reviews/?platform=pc"},
+				{Score: 88, Source: "Metacritic User Score", URL: "https://www.metacritic.com/game/portal-2/user-reviews/?platform=pc"},
+			}})
+		case "/games/prices/v3":
+			if r.Method != "POST" || r.URL.Query().Get("country") != "US" {
+				http.Error(w, "bad request", http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode([]ITADPrices{{ID: "portal-2", Deals: []ITADDeal{
+				{Shop: ITADEntity{Name: "GOG"}, URL: standIn.URL + "/out/gog", Platforms: []ITADEntity{{Name: "Windows"}, {Name: "Linux"}}, DRM: []ITADEntity{{Name: "DRM Free"}}},
+				{Shop: ITADEntity{Name: "Unknown Shop"}, URL: standIn.URL + "/out/unknown"},
+			}}})
+		case "/out/gog":
+			http.Redirect(w, r, "/game/portal_2", http.StatusFound)
+		case "/game/portal_2":
+		case "/steam/app/620":
+			w.Write([]byte("<html><body></body></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(standIn.Close)
+	return standIn
+}
+
+func (standIn *itadStandIn) client() *ITADClient {
+	client := NewITADClient(ITADConfig{Key: "key", BaseURL: standIn.URL, Country: "US"})
+	client.SiteURL = standIn.URL
+	return client
+}
+
+func TestITADLookup(t *testing.T) {
+	client := newITADStandIn(t, false).client()
+
+	id, err := client.Lookup("620")
+	if err != nil || id != "portal-2" {
+		t.Fatalf("Lookup(620) = %q, %v", id, err)
+	}
+
+	if _, err = client.Lookup("999"); err == nil {
+		t.Error("Lookup(999) found a game, the stand-in has none")
+	}
+}
+
+func TestFetchITAD(t *testing.T) {
+	standIn := newITADStandIn(t, false)
+	game := newTestGame("Portal 2")
+
+	if err := game.fetchITAD(standIn.client(), "620"); err != nil {
+		t.Fatal(err)
+	}
+
+	if rating := game.Data.Ratings["Metascore"]; rating.Score != 95 {
+		t.Errorf("Metascore rating = %+v, want a score of 95", rating)
+	}
+	if rows, _ := game.Reception(); !strings.Contains(rows, "{{Infobox game/row/reception|Metacritic|portal-2|95}}") {
+		t.Errorf("the Metascore was not written as the Metacritic reception row:%s", rows)
+	}
+
+	store, ok := game.Data.Stores["GOG.com"]
+	if !ok {
+		t.Fatalf("GOG.com was not added, stores: %v", game.Data.Stores)
+	}
+	if store.URL != standIn.URL+"/game/portal_2" {
+		t.Errorf("GOG.com link = %q, want the redirect followed", store.URL)
+	}
+	if store.Platforms != "Windows, Linux" || strings.Join(store.DRM, ",") != "DRM Free" {
+		t.Errorf("GOG.com row = %+v", store)
+	}
+
+	if !containsString(game.Data.UnknownStores, "Unknown Shop") {
+		t.Errorf("UnknownStores = %v, want Unknown Shop", game.Data.UnknownStores)
+	}
+	if standIn.hits["/out/unknown"] != 0 {
+		t.Error("the link of a store missing from the registry was followed")
+	}
+}
+
+func TestFetchITADNoMatch(t *testing.T) {
+	standIn := newITADStandIn(t, false)
+	game := newTestGame("Unknown")
+
+	if err := game.fetchITAD(standIn.client(), "999"); err == nil {
+		t.Fatal("fetchITAD found a game the stand-in does not have")
+	}
+	if len(game.Data.Stores) != 0 || standIn.hits["/games/prices/v3"] != 0 {
+		t.Error("prices were fetched without a match")
+	}
+}
+
+func TestLoadITADScrapesOnError(t *testing.T) {
+	standIn := newITADStandIn(t, true)
+	game := newTestGame("Portal 2")
+
+	game.loadITAD(standIn.client(), "620")
+	if standIn.hits["/steam/app/620"] != 1 {
+		t.Errorf("the game page was scraped %d time(s) after the API failed, want 1", standIn.hits["/steam/app/620"])
+	}
+
+	// Without a key the API is not used at all
+	standIn = newITADStandIn(t, false)
+	client := standIn.client()
+	client.Key = ""
+	game.loadITAD(client, "620")
+	if standIn.hits["/games/lookup/v1"] != 0 || standIn.hits["/steam/app/620"] != 1 {
+		t.Errorf("hits without a key = %v, want only the game page", standIn.hits)
+	}
+}
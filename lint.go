@@ -0,0 +1,148 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+//go:embed schema.json
+var defaultSchema []byte
+
+var Schema TemplateSchema
+
+var (
+	refRegex      = regexp.MustCompile(`(?s)<ref[^>/]*/>|<ref[^>]*>.*?</ref>`)
+	innerTemplate = regexp.MustCompile(`\{\{[^{}]*\}\}`)
+)
+
+// Loads the template schema from the given file, or from the embedded one if
+// the file does not exist
+func LoadSchema(fileName string) (schema TemplateSchema, err error) {
+	data := defaultSchema
+	if len(fileName) != 0 {
+		if override, readErr := os.ReadFile(fileName); readErr == nil {
+			data = override
+		} else if !os.IsNotExist(readErr) {
+			return schema, readErr
+		}
+	}
+
+	if err = json.Unmarshal(data, &schema); err != nil {
+		return schema, fmt.Errorf("failed to parse the template schema (%s)", err)
+	}
+
+	for template, params := range schema.Templates {
+		for param, kind := range params {
+			if _, ok := schema.Enums[kind]; kind != "text" && !ok {
+				return schema, fmt.Errorf("unknown value type '%s' for '%s' of '%s' in the template schema", kind, param, template)
+			}
+		}
+	}
+	return
+}
+
+func (schema *TemplateSchema) params(name string) (map[string]string, bool) {
+	for template, params := range schema.Templates {
+		if templateNameIs(name, template) {
+			return params, true
+		}
+	}
+	return nil, false
+}
+
+// Checks every template described by the schema for duplicate, unknown and
+// invalid parameters, templates missing from the schema are not checked
+func Lint(text string, schema TemplateSchema) (issues []LintIssue) {
+	for _, template := range ParseTemplates(text) {
+		params, ok := schema.params(template.Name)
+		if !ok {
+			continue
+		}
+
+		var seen []string
+		for _, param := range template.Params {
+			issue := LintIssue{Line: strings.Count(text[:param.Start], "\n") + 1, Template: template.Name}
+
+			if containsString(seen, param.Name) {
+				issue.Message = fmt.Sprintf("duplicate parameter '%s'", param.Name)
+				issues = append(issues, issue)
+				continue
+			}
+			seen = append(seen, param.Name)
+
+			kind, ok := params[param.Name]
+			if !ok {
+				kind, ok = params["*"]
+			}
+
+			if !ok {
+				issue.Message = fmt.Sprintf("unknown parameter '%s'", param.Name)
+				issues = append(issues, issue)
+			} else if value := enumValue(param.Value); kind != "text" && len(value) != 0 && !containsFold(schema.Enums[kind], value) {
+				issue.Message = fmt.Sprintf("invalid value '%s' for '%s' (expected %s)", strings.Join(strings.Fields(value), " "), param.Name, strings.Join(schema.Enums[kind], "/"))
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Line < issues[j].Line
+	})
+	return
+}
+
+// The value without references and templates such as {{cn}}
+func enumValue(value string) string {
+	value = refRegex.ReplaceAllString(value, "")
+	for innerTemplate.MatchString(value) {
+		value = innerTemplate.ReplaceAllString(value, "")
+	}
+	return strings.TrimSpace(value)
+}
+
+func containsFold(list []string, value string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (issue LintIssue) String() string {
+	return fmt.Sprintf("%d: {{%s}} %s", issue.Line, issue.Template, issue.Message)
+}
+
+// Lints the given articles, exiting with a non-zero code if any has issues
+func RunLint(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: lint <wikitext file>...")
+		os.Exit(2)
+	}
+
+	failed := false
+	for _, fileName := range args {
+		data, err := os.ReadFile(fileName)
+		if err != nil {
+			Log.Errorf("Failed to read '%s'... (%s)", fileName, err)
+			failed = true
+			continue
+		}
+
+		issues := Lint(string(data), Schema)
+		for _, issue := range issues {
+			fmt.Printf("%s:%s\n", fileName, issue)
+		}
+		failed = failed || len(issues) != 0
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+	Log.Infof("No issues found")
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLintGeneratedArticle(t *testing.T) {
+	for name, article := range generatedArticles() {
+		for _, issue := range Lint(article, Schema) {
+			t.Errorf("%s article:%s", name, issue)
+		}
+	}
+}
+
+func TestLintSystemRequirements(t *testing.T) {
+	game := newTestGame("Portal 2")
+	game.Data.Platforms.Windows = true
+	game.Data.Platforms.Linux = true
+	game.Data.PCRequirements = Requirement{
+		"minimum":     `<strong>Minimum:</strong><br><ul class="bb_ul"><li><strong>OS:</strong> Windows 7<br></li><li><strong>Memory:</strong> 2 GB RAM<br></li><li><strong>Additional Notes:</strong> Requires a controller</li></ul>`,
+		"recommended": `<strong>Recommended:</strong><br><ul class="bb_ul"><li><strong>OS:</strong> Windows 10<br></li><li><strong>Memory:</strong> 4 GB RAM<br></li><li><strong>Additional Notes:</strong> SSD recommended</li></ul>`,
+	}
+	game.Data.LinuxRequirements = Requirement{"minimum": `<strong>Minimum:</strong><br>Ubuntu 12.04`}
+
+	specs := game.OutputSpecs()
+	for _, issue := range Lint(specs, Schema) {
+		t.Errorf("%s", issue)
+	}
+
+	for _, want := range []string{
+		"|minOS     = 7\n",
+		"|recRAM    = 4 GB\n",
+		"|notes     = {{ii}} Requires a controller\n{{ii}} SSD recommended\n",
+		"|OSfamily  = Linux\n|minOS     = Ubuntu 12.04\n",
+	} {
+		if !strings.Contains(specs, want) {
+			t.Errorf("the requirements do not contain %q:\n%s", want, specs)
+		}
+	}
+}
+
+func TestLintIssues(t *testing.T) {
+	text := "{{Video\n|vsync = maybe\n|vsync = true\n|frobnicate = true\n}}\n{{Unknown|x=1}}"
+
+	var messages []string
+	for _, issue := range Lint(text, Schema) {
+		messages = append(messages, issue.Message)
+	}
+	if len(messages) != 3 {
+		t.Errorf("issues = %q, want the invalid value, the duplicate and the unknown parameter", messages)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+var Log = NewLogger()
+
+var logLevelNames = map[LogLevel]string{
+	LOG_DEBUG: "debug",
+	LOG_INFO:  "info",
+	LOG_WARN:  "warning",
+	LOG_ERROR: "error",
+}
+
+func NewLogger() *Logger {
+	return &Logger{
+		Level:  LOG_INFO,
+		Output: os.Stderr,
+		Fields: make(map[string]string),
+	}
+}
+
+// Sets a field added to every following entry, an empty value removes it
+func (logger *Logger) SetField(key, value string) {
+	if len(value) == 0 {
+		delete(logger.Fields, key)
+	} else {
+		logger.Fields[key] = value
+	}
+}
+
+func (logger *Logger) Debugf(format string, args ...interface{}) {
+	logger.log(LOG_DEBUG, format, args...)
+}
+
+func (logger *Logger) Infof(format string, args ...interface{}) {
+	logger.log(LOG_INFO, format, args...)
+}
+
+func (logger *Logger) Warnf(format string, args ...interface{}) {
+	logger.log(LOG_WARN, format, args...)
+}
+
+func (logger *Logger) Errorf(format string, args ...interface{}) {
+	logger.log(LOG_ERROR, format, args...)
+}
+
+func (logger *Logger) log(level LogLevel, format string, args ...interface{}) {
+	if level < logger.Level {
+		return
+	}
+	message := strings.TrimSuffix(fmt.Sprintf(format, args...), "\n")
+
+	if logger.JSON {
+		entry := map[string]string{
+			"time":  time.Now().Format(time.RFC3339),
+			"level": logLevelNames[level],
+			"msg":   message,
+		}
+		for key, value := range logger.Fields {
+			entry[key] = value
+		}
+		data, _ := json.Marshal(entry)
+		fmt.Fprintln(logger.Output, string(data))
+		return
+	}
+
+	keys := make([]string, 0, len(logger.Fields))
+	for key := range logger.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var prefix string
+	for _, key := range keys {
+		prefix += fmt.Sprintf("[%s=%s] ", key, logger.Fields[key])
+	}
+	if level >= LOG_WARN {
+		prefix += capitaliseTitle(logLevelNames[level]) + ": "
+	}
+	fmt.Fprintln(logger.Output, prefix+message)
+}
+
+func NewProgress(steps ...string) *Progress {
+	return &Progress{Steps: steps}
+}
+
+// Logs the step with its position among the registered steps, steps which
+// are not registered are logged without one
+func (progress *Progress) Step(name string) {
+	for i, step := range progress.Steps {
+		if step == name {
+			progress.Current = i + 1
+			Log.Infof("* [%d/%d] %s", progress.Current, len(progress.Steps), name)
+			return
+		}
+	}
+	Log.Infof("* %s", name)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Generates the article with the log written to a buffer, returning the
+// progress lines
+func generationSteps(game Game) (steps []string) {
+	var buffer bytes.Buffer
+	output := Log.Output
+	Log.Output = &buffer
+	defer func() { Log.Output = output }()
+
+	GenerateArticle("620", &game)
+
+	for _, line := range strings.Split(buffer.String(), "\n") {
+		if strings.HasPrefix(line, "* ") {
+			steps = append(steps, line)
+		}
+	}
+	return
+}
+
+func TestArticleStepsNumbering(t *testing.T) {
+	config := AppConfig
+	defer func() { AppConfig = config }()
+
+	for _, stubBelow := range []int{0, 101} {
+		AppConfig.Completeness = CompletenessConfig{StubBelow: stubBelow}
+
+		game := newTestGame("Portal 2")
+		game.Data.Platforms.Windows = true
+		game.Data.SupportedLanguages = "English<strong>*</strong>"
+		game.ProcessLanguages()
+		game.Data.PCRequirements = Requirement{"minimum": "<strong>Minimum:</strong><br>OS: 7"}
+		steps := generationSteps(game)
+
+		// Every registered step is run once and in order, marking the
+		// article as a stub is left out of the count
+		var want []string
+		for i, step := range articleSteps {
+			want = append(want, fmt.Sprintf("* [%d/%d] %s", i+1, len(articleSteps), step))
+		}
+		if stubBelow != 0 {
+			want = append(want, "* Stub")
+		}
+
+		if strings.Join(steps, "\n") != strings.Join(want, "\n") {
+			t.Errorf("stub below %d, steps logged:\n%s\nwant:\n%s", stubBelow, strings.Join(steps, "\n"), strings.Join(want, "\n"))
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"sort"
@@ -9,64 +12,249 @@ import (
 )
 
 func main() {
+	flags := flag.NewFlagSet("steam2pcgw", flag.ContinueOnError)
+	version := flags.Bool("v", false, "print the version and exit")
+	quiet := flags.Bool("quiet", false, "only log warnings and errors")
+	verbose := flags.Bool("verbose", false, "also log debug messages")
+	logFormat := flags.String("log-format", "text", "format of the log written to stderr (text or json)")
+	toStdout := flags.Bool("stdout", false, "also write the generated article to stdout")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: steam2pcgw [-quiet|-verbose] [-log-format text|json] [-stdout] [appid | taxonomy-report | update | parse | lint | export | publish ...]")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		os.Exit(2)
+	}
 
-	if len(os.Args) > 1 && strings.ToLower(os.Args[1]) == "-v" {
+	if *version {
 		fmt.Println(APP_NAME, VERSION, "(", GH_LINK, ")")
 		return
 	}
 
-	fmt.Println("Running", APP_NAME, VERSION, "(", GH_LINK, ")")
+	switch {
+	case *quiet:
+		Log.Level = LOG_WARN
+	case *verbose:
+		Log.Level = LOG_DEBUG
+	}
+
+	switch *logFormat {
+	case "json":
+		Log.JSON = true
+	case "text":
+	default:
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	Log.Infof("Running %s %s (%s)", APP_NAME, VERSION, GH_LINK)
+
+	var err error
+	AppConfig, err = LoadConfig(CONFIG_FILE)
+	if err != nil {
+		Log.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	StoreRegistry, err = LoadStores(AppConfig.StoresFile)
+	if err != nil {
+		Log.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	Schema, err = LoadSchema(AppConfig.SchemaFile)
+	if err != nil {
+		Log.Errorf("%s", err)
+		os.Exit(1)
+	}
 
+	args := flags.Args()
 	gameId := ""
-	var err error = nil
+	if len(args) != 0 {
+		switch strings.ToLower(args[0]) {
+		case "taxonomy-report":
+			RunTaxonomyReport()
+			return
+		case "update":
+			RunUpdate(args[1:])
+			return
+		case "parse":
+			RunParse(args[1:])
+			return
+		case "lint":
+			RunLint(args[1:])
+			return
+		case "export":
+			RunExport(args[1:])
+			return
+		case "publish":
+			RunPublish(args[1:])
+			return
+		default:
+			if gameId, err = ResolveAppID(strings.Join(args, " "), false); err != nil {
+				Log.Errorf("%s", err)
+				os.Exit(1)
+			}
+		}
+	}
 
 	// Ask for input from the user
-	for len(gameId) == 0 || err != nil {
-		print("Insert the Steam app ID: ")
-		gameId, err = TakeInput()
+	for len(gameId) == 0 {
+		fmt.Fprint(os.Stderr, "Insert the Steam app ID, a store link or the name of the game: ")
+		input, err := TakeInput()
+		if err == io.EOF {
+			fmt.Fprintln(os.Stderr)
+			os.Exit(1)
+		} else if err == errEmptyInput {
+			Log.Errorf("%s", err)
+			continue
+		} else if err != nil {
+			Log.Errorf("Failed to read the input... (%s)", err)
+			os.Exit(1)
+		}
+
+		if gameId, err = ResolveAppID(input, true); err != nil {
+			if err == io.EOF {
+				os.Exit(1)
+			}
+			Log.Errorf("%s", err)
+		}
+	}
+
+	game, err := LoadGame(gameId)
+	if err != nil {
+		Log.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	if AppConfig.PCGW.CheckExisting {
+		Log.Infof("Looking for an existing PCGW article...")
+		existing, err := NewPCGWClient(AppConfig.PCGW).FindExisting(gameId, game.Title().Title)
 		if err != nil {
-			fmt.Println(err)
+			Log.Warnf("Failed to look for an existing PCGW article... (%s)", err)
+		} else if len(existing) != 0 {
+			Log.Warnf("PCGW already has an article for this game: %s", strings.Join(existing, ", "))
+			if AppConfig.PCGW.StopIfExists {
+				Log.Errorf("Process stopped! (set \"stop_if_exists\" to false in the config to generate it anyway)")
+				os.Exit(1)
+			}
+		}
+	}
+
+	article := GenerateArticle(gameId, &game)
+	if err = os.WriteFile(fmt.Sprintf("output/%s.txt", gameId), []byte(article), 0777); err != nil {
+		Log.Errorf("Failed to create the output file... Process stopped!")
+		os.Exit(1)
+	}
+
+	if *toStdout {
+		fmt.Print(article)
+	}
+
+	title := game.Title()
+	Log.Infof("Article title: %s", title.Title)
+	if len(title.Redirects) != 0 {
+		Log.Infof("Suggested redirects: %s", strings.Join(title.Redirects, ", "))
+		if err = title.WriteRedirects(gameId); err != nil {
+			Log.Warnf("Failed to write the redirect pages... (%s)", err)
 		}
 	}
 
-	fmt.Println("Fetching game app details...")
+	issues := Lint(article, Schema)
+	for _, issue := range issues {
+		Log.Warnf("output/%s.txt:%s", gameId, issue)
+	}
+	if len(issues) != 0 {
+		Log.Warnf("The article has %d lint issue(s), check them before publishing", len(issues))
+	}
+
+	for _, conflict := range game.Data.DRMConflicts {
+		Log.Warnf("DRM conflict: %s", conflict)
+	}
+
+	if len(game.Data.NewCompanies) != 0 {
+		Log.Warnf("Companies without a Company: page on PCGW (create them or add an alias): %s", strings.Join(game.Data.NewCompanies, ", "))
+	}
+
+	if len(game.Data.UnknownStores) != 0 {
+		Log.Warnf("Stores missing from the store registry (not added to Availability): %s", strings.Join(game.Data.UnknownStores, ", "))
+	}
+
+	if err = game.WriteSources(gameId, article); err != nil {
+		Log.Warnf("Failed to write the sources of the article... (%s)", err)
+	}
+
+	Log.Infof("%s", game.Data.Completeness)
+	if err = game.Data.Completeness.Write(gameId); err != nil {
+		Log.Warnf("Failed to write the completeness score... (%s)", err)
+	}
+
+	review := game.Review(gameId, article)
+	if err = review.Write(gameId); err != nil {
+		Log.Warnf("Failed to write the review report... (%s)", err)
+	} else {
+		Log.Infof("%d field(s) need a review, see output/%s.review.txt", len(review.Items), gameId)
+	}
+
+	report := NewTaxonomyReport()
+	report.Add(&game)
+	if err = os.WriteFile(fmt.Sprintf("output/%s.taxonomy.txt", gameId), []byte(report.String()), 0777); err != nil {
+		Log.Warnf("Failed to write the taxonomy report...")
+	}
+
+	Log.Infof("Successfully parsed information for game: '%s'", SanitiseName(game.Data.Name, true))
+}
+
+// Fetches the app details (or reads them from the cache) along with every
+// other source used by the article
+func LoadGame(gameId string) (game Game, err error) {
+	Log.SetField("app", gameId)
+	Log.Infof("Fetching game app details...")
 
 	var gameJson []byte
 	gameJson, err = ParseGame(gameId)
 	if err != nil {
-		fmt.Println(err)
 		return
 	}
 
-	game, err := UnmarshalGame(gameJson)
+	game, err = UnmarshalGame(gameJson)
 	if err != nil {
-		fmt.Printf("An error occurred while attempting to unmarshal the JSON... (%s)\n", err)
-		return
+		err = fmt.Errorf("An error occurred while attempting to unmarshal the JSON... (%s)", err)
 	} else if !game.Success {
-		fmt.Println("The app ID provided does not exist or does not have a Store page...")
-		return
+		err = errors.New("The app ID provided does not exist or does not have a Store page...")
 	}
+	return
+}
 
-	outputFile, err := os.Create(fmt.Sprintf("output/%s.txt", gameId))
-	if err != nil {
-		fmt.Println("Failed to create the output file... Process stopped!")
-		return
-	}
+// Steps of GenerateArticle, in the order they are run. Every one of them is
+// run for every article, marking the article as a stub is logged apart as it
+// only happens to some
+var articleSteps = []string{
+	"Cover", "Developers", "Publishers", "Release dates", "Reception", "Monetization taxonomy", "Taxonomy",
+	"Introduction", "Availability", "Monetization", "Microtransactions", "DLC",
+	"Configuration file location", "Save game data location", "Save game cloud syncing",
+	"Video", "Input", "Audio", "Languages", "Network", "API", "Middleware", "System requirements",
+	"References",
+}
 
-	fmt.Println("* [1/25] Adding stub")
-	outputFile.WriteString("{{stub}}\n")
+func GenerateArticle(gameId string, game *Game) string {
+	var output strings.Builder
 
-	fmt.Println("* [2/25] Adding app cover")
-	outputFile.WriteString(fmt.Sprintf("{{Infobox game\n|cover        = %s cover.jpg", SanitiseName(game.Data.Name, true)))
+	title := game.Title()
+	progress := NewProgress(articleSteps...)
 
-	fmt.Println("* [3/25] Adding app developers")
-	outputFile.WriteString("\n|developers   = ")
+	progress.Step("Cover")
+	output.WriteString(fmt.Sprintf("{{Infobox game\n|cover        = %s cover.jpg", title.Title))
+
+	progress.Step("Developers")
+	output.WriteString("\n|developers   = ")
 	for _, developer := range game.Data.Developers {
-		outputFile.WriteString(fmt.Sprintf("\n{{Infobox game/row/developer|%s}}", SanitiseName(developer, false)))
+		output.WriteString(fmt.Sprintf("\n{{Infobox game/row/developer|%s}}%s", SanitiseName(developer, false), game.CompanyNote(developer)))
 	}
 
-	fmt.Println("* [4/25] Adding app publishers")
-	outputFile.WriteString("\n|publishers   = ")
+	progress.Step("Publishers")
+	output.WriteString("\n|publishers   = ")
 	for _, publisher := range game.Data.Publishers {
 		if len(game.Data.Publishers) == 1 {
 			skip := false
@@ -80,11 +268,11 @@ func main() {
 				continue
 			}
 		}
-		outputFile.WriteString(fmt.Sprintf("\n{{Infobox game/row/publisher|%s}}", SanitiseName(publisher, false)))
+		output.WriteString(fmt.Sprintf("\n{{Infobox game/row/publisher|%s}}%s", SanitiseName(publisher, false), game.CompanyNote(publisher)))
 	}
 
-	fmt.Println("* [5/25] Adding app release date")
-	outputFile.WriteString("\n|engines      =\n<!-- {{Infobox game/row/engine|}} -->\n|release dates= ")
+	progress.Step("Release dates")
+	output.WriteString("\n|engines      =\n<!-- {{Infobox game/row/engine|}} -->\n|release dates= ")
 
 	date := ""
 	if game.HasSteamGenre(EarlyAccess) {
@@ -100,50 +288,34 @@ func main() {
 	}
 
 	if game.Data.Platforms.Windows {
-		outputFile.WriteString(fmt.Sprintf("\n{{Infobox game/row/date|Windows| %s }}", date))
+		output.WriteString(fmt.Sprintf("\n{{Infobox game/row/date|Windows| %s }}", date))
 	}
 
 	if game.Data.Platforms.MAC {
-		outputFile.WriteString(fmt.Sprintf("\n{{Infobox game/row/date|OS X| %s }}", date))
+		output.WriteString(fmt.Sprintf("\n{{Infobox game/row/date|OS X| %s }}", date))
 	}
 
 	if game.Data.Platforms.Linux {
-		outputFile.WriteString(fmt.Sprintf("\n{{Infobox game/row/date|Linux| %s }}", date))
+		output.WriteString(fmt.Sprintf("\n{{Infobox game/row/date|Linux| %s }}", date))
 	}
 
-	fmt.Println("* [6/25] Adding reception score")
-	outputFile.WriteString("\n|reception    = \n{{Infobox game/row/reception|Metacritic|")
-	if game.Data.Metacritic != nil {
-		outputFile.WriteString(fmt.Sprintf("%s|%d}}", strings.TrimPrefix(game.Data.Metacritic.URL, "https://metacritic.com/game/pc/"), game.Data.Metacritic.Score))
-	} else if val, ok := game.Data.Ratings["Metascore"]; ok {
-		outputFile.WriteString(fmt.Sprintf("%s|%d}}", strings.TrimPrefix(val.URL, "https://metacritic.com/game/pc/"), val.Score))
-	} else {
-		outputFile.WriteString("link|rating}}")
-	}
+	progress.Step("Reception")
+	reception, igdbField := game.Reception()
+	output.WriteString("\n|reception    = ")
+	output.WriteString(reception)
 
-	outputFile.WriteString("\n{{Infobox game/row/reception|OpenCritic|")
-	if val, ok := game.Data.Ratings["OpenCritic"]; ok {
-		outputFile.WriteString(fmt.Sprintf("%s|%d}}", strings.TrimPrefix(val.URL, "https://opencritic.com/game/"), val.Score))
-	} else {
-		outputFile.WriteString("link|rating}}")
-	}
-	outputFile.WriteString("\n{{Infobox game/row/reception|IGDB|link|rating}}")
+	game.InferMonetization()
+	monetization := game.Data.Monetization.Taxonomy()
+	progress.Step("Monetization taxonomy")
+	Log.Debugf("Monetization: %s", monetization)
+	output.WriteString("\n|taxonomy     =\n{{Infobox game/row/taxonomy/monetization      | ")
+	output.WriteString(monetization + " }}")
 
-	outputFile.WriteString("\n|taxonomy     =\n{{Infobox game/row/taxonomy/monetization      | ")
-	if game.Data.IsFree {
-		fmt.Println("* [7/25] Game is F2P")
-		outputFile.WriteString(("Free-to-play }}"))
-	} else {
-		fmt.Println("* [7/25] Game is not F2P")
-		outputFile.WriteString(("One-time game purchase }}"))
-	}
-
-	fmt.Println("* [8/25] Taxonomy...")
-	outputFile.WriteString("\n{{Infobox game/row/taxonomy/microtransactions | ")
-	if !game.HasCategory(InAppPurchases) {
-		outputFile.WriteString("None ")
-	}
-	outputFile.WriteString("}}\n{{Infobox game/row/taxonomy/modes             | ")
+	progress.Step("Taxonomy")
+	game.DetectMicrotransactions()
+	output.WriteString("\n{{Infobox game/row/taxonomy/microtransactions | ")
+	output.WriteString(game.Data.Microtransactions.Taxonomy())
+	output.WriteString(" }}\n{{Infobox game/row/taxonomy/modes             | ")
 
 	modes := ""
 
@@ -156,73 +328,75 @@ func main() {
 	}
 
 	modes = strings.TrimSuffix(modes, ", ")
-	outputFile.WriteString(modes)
+	output.WriteString(modes)
 
-	outputFile.WriteString(" }}\n{{Infobox game/row/taxonomy/pacing            | ")
-	outputFile.WriteString(game.Data.Pacing)
+	output.WriteString(" }}\n{{Infobox game/row/taxonomy/pacing            | ")
+	output.WriteString(game.Data.Pacing)
 
-	outputFile.WriteString(" }}\n{{Infobox game/row/taxonomy/perspectives      | ")
-	outputFile.WriteString(game.Data.Perspectives)
+	output.WriteString(" }}\n{{Infobox game/row/taxonomy/perspectives      | ")
+	output.WriteString(game.Data.Perspectives)
 
-	outputFile.WriteString(" }}\n{{Infobox game/row/taxonomy/controls          | ")
-	outputFile.WriteString(game.Data.Controls)
+	output.WriteString(" }}\n{{Infobox game/row/taxonomy/controls          | ")
+	output.WriteString(game.Data.Controls)
 
-	outputFile.WriteString(" }}\n{{Infobox game/row/taxonomy/genres            | ")
-	outputFile.WriteString(game.Data.Genres)
+	output.WriteString(" }}\n{{Infobox game/row/taxonomy/genres            | ")
+	output.WriteString(game.Data.Genres)
 
-	outputFile.WriteString(" }}\n{{Infobox game/row/taxonomy/sports            | ")
-	outputFile.WriteString(game.Data.Sports)
+	output.WriteString(" }}\n{{Infobox game/row/taxonomy/sports            | ")
+	output.WriteString(game.Data.Sports)
 
-	outputFile.WriteString(" }}\n{{Infobox game/row/taxonomy/vehicles          | ")
-	outputFile.WriteString(game.Data.Vehicles)
+	output.WriteString(" }}\n{{Infobox game/row/taxonomy/vehicles          | ")
+	output.WriteString(game.Data.Vehicles)
 
-	outputFile.WriteString(" }}\n{{Infobox game/row/taxonomy/art styles        | ")
-	outputFile.WriteString(game.Data.ArtStyles)
+	output.WriteString(" }}\n{{Infobox game/row/taxonomy/art styles        | ")
+	output.WriteString(game.Data.ArtStyles)
 
-	outputFile.WriteString(" }}\n{{Infobox game/row/taxonomy/themes            | ")
-	outputFile.WriteString(game.Data.Themes)
+	output.WriteString(" }}\n{{Infobox game/row/taxonomy/themes            | ")
+	output.WriteString(game.Data.Themes)
 
-	outputFile.WriteString(" }}\n{{Infobox game/row/taxonomy/series            | ")
+	output.WriteString(" }}\n{{Infobox game/row/taxonomy/series            | ")
 	if len(game.Data.Franchise) != 0 {
-		outputFile.WriteString(game.Data.Franchise)
-		outputFile.WriteString(" }}\n")
+		output.WriteString(game.Data.Franchise)
+		output.WriteString(" }}\n")
 	} else {
-		outputFile.WriteString("}}\n")
+		output.WriteString("}}\n")
 	}
 
-	outputFile.WriteString(fmt.Sprintf("|steam appid  = %s\n|steam appid side = ", gameId))
+	output.WriteString(fmt.Sprintf("|steam appid  = %s\n|steam appid side = ", gameId))
 	if game.Data.Dlc != nil {
 		var dlcs string = ""
 		for _, v := range game.Data.Dlc {
 			dlcs += fmt.Sprintf("%v, ", v)
 		}
 		dlcs = strings.TrimSuffix(dlcs, ", ")
-		outputFile.WriteString(dlcs)
+		output.WriteString(dlcs)
 	}
-	outputFile.WriteString("\n|gogcom id    = \n|gogcom id side = \n|official site= ")
+	output.WriteString(fmt.Sprintf("\n|gogcom id    = %s\n|gogcom id side = %s\n|official site= ", game.Data.GogID, strings.Join(game.Data.GogSideIDs, ", ")))
 
 	if game.Data.Website != nil {
-		outputFile.WriteString(*game.Data.Website)
+		output.WriteString(*game.Data.Website)
 	} else {
-		outputFile.WriteString(game.Data.SupportInfo.URL)
+		output.WriteString(game.Data.SupportInfo.URL)
 	}
-	outputFile.WriteString("\n|hltb         = \n|igdb         = <!-- Only needs to be set if there is no IGDB reception row -->\n|lutris       = \n|mobygames    = \n|strategywiki = \n|wikipedia    = \n|winehq       = \n|license      = commercial\n}}")
+	ids := game.Data.ExternalIDs
+	output.WriteString(fmt.Sprintf("\n|hltb         = %s\n|igdb         = %s<!-- Only needs to be set if there is no IGDB reception row -->\n|lutris       = %s\n|mobygames    = %s\n|strategywiki = %s\n|wikipedia    = %s\n|winehq       = %s\n|license      = commercial\n}}",
+		ids["hltb"], igdbField, ids["lutris"], ids["mobygames"], ids["strategywiki"], ids["wikipedia"], ids["winehq"]))
 
-	fmt.Println("* [9/25] Processing introduction...")
-	outputFile.WriteString("\n\n{{Introduction\n|introduction      = ")
-	// outputFile.WriteString(removeTags(game.Data.AboutTheGame))
+	progress.Step("Introduction")
+	output.WriteString("\n\n{{Introduction\n|introduction      = ")
+	// output.WriteString(removeTags(game.Data.AboutTheGame))
 
-	outputFile.WriteString("\n\n|release history   = ")
+	output.WriteString("\n\n|release history   = ")
 
-	outputFile.WriteString("\n\n|current state     = ")
-	outputFile.WriteString("\n}}")
+	output.WriteString("\n\n|current state     = ")
+	output.WriteString("\n}}")
 
-	outputFile.WriteString("\n\n'''General information'''")
-	outputFile.WriteString("\n{{mm}} [https://steamcommunity.com/app/" + gameId + "/discussions/ Steam Community Discussions]")
+	output.WriteString("\n\n'''General information'''")
+	output.WriteString("\n{{mm}} [https://steamcommunity.com/app/" + gameId + "/discussions/ Steam Community Discussions]")
 
-	fmt.Println("* [10/25] Processing Availability!")
+	progress.Step("Availability")
 
-	outputFile.WriteString("\n\n==Availability==\n{{Availability|\n")
+	output.WriteString("\n\n==Availability==\n{{Availability|\n")
 
 	platforms := ""
 	if game.Data.Platforms.Windows {
@@ -276,127 +450,81 @@ func main() {
 		editionList += " also available"
 	}
 
-	outputFile.WriteString(fmt.Sprintf("{{Availability/row| Steam | %s | Steam | %s | | %s ", gameId, editionList, platforms))
+	steamNotes := strings.TrimSpace(editionList + " " + game.AvailabilityNotes())
+	output.WriteString(fmt.Sprintf("{{Availability/row| Steam | %s | %s | %s | | %s ", gameId, game.SteamDRM(), steamNotes, platforms))
 
 	if len(game.Data.Packages) == 0 {
-		outputFile.WriteString("| unavailable ")
+		output.WriteString("| unavailable ")
 	}
 
-	outputFile.WriteString("}}")
+	output.WriteString("}}")
 
-	for store, data := range game.Data.Stores {
-		outputFile.WriteString(fmt.Sprintf("\n{{Availability/row| %s | %s | DRM | %s | | %s }}", store, data.URL, editionList, data.Platforms))
+	for _, store := range game.SortedStores() {
+		data := game.Data.Stores[store]
+		notes := strings.TrimSpace(editionList + " " + game.SubscriptionNote(store))
+		output.WriteString(fmt.Sprintf("\n{{Availability/row| %s | %s | %s | %s | | %s }}", store, data.URL, game.ResolveDRM(store, data), notes, data.Platforms))
 	}
+	output.WriteString(game.SubscriptionRows())
 
-	outputFile.WriteString("\n}}")
+	output.WriteString("\n}}")
 
-	// Third party account check
-	if len(game.Data.ExternalAccountNotice) != 0 {
-		outputFile.WriteString(fmt.Sprintf("\n{{ii}} Requires 3rd-Party Account: %s", game.Data.ExternalAccountNotice))
-	}
-
-	// DRM check
-	drms := ""
-	if strings.Contains(game.Data.DRMNotice, "Denuvo") {
-		drms += "{{DRM|Denuvo}}, "
-	}
-	// if strings.Contains(game.Data.PCRequirements["minimum"].(string), "VMProtect") {
-	// 	drms += "{{DRM|VMProtect}}, "
-	// }
-
-	drms = strings.TrimSuffix(drms, ", ")
-	if len(drms) == 0 {
-		drms += game.Data.DRMNotice
-		if len(drms) != 0 {
-			outputFile.WriteString(fmt.Sprintf("\n{{ii}} All versions require %s.", drms))
-		}
-	}
+	// DRM, launcher and account requirements
+	output.WriteString(game.DRMNotes())
 
 	if len(editionList) > 1 {
-		outputFile.WriteString("\n\n===Version differences===\n{{ii}} ")
-		outputFile.WriteString(editionList)
+		output.WriteString("\n\n===Version differences===\n{{ii}} ")
+		output.WriteString(editionList)
 	}
 
-	outputFile.WriteString("\n\n<!-- PAGE GENERATED BY STEAM2PCGW -->")
-
-	fmt.Println("* [11/25] Processing Monetization!")
-	outputFile.WriteString("\n\n==Monetization==\n")
+	output.WriteString("\n\n<!-- PAGE GENERATED BY STEAM2PCGW -->")
 
-	outputFile.WriteString("{{Monetization")
-	outputFile.WriteString("\n|ad-supported                = ")
-	outputFile.WriteString("\n|dlc                         = ")
-	outputFile.WriteString("\n|expansion pack              = ")
-	outputFile.WriteString("\n|freeware                    = ")
-	outputFile.WriteString("\n|free-to-play                = ")
-	if game.Data.IsFree {
-		outputFile.WriteString("The game has such monetization.")
-	}
-	outputFile.WriteString("\n|one-time game purchase      = ")
-	if !game.Data.IsFree {
-		outputFile.WriteString("The game requires an upfront purchase to access.")
-	}
-	outputFile.WriteString("\n|sponsored                   = ")
-	outputFile.WriteString("\n|subscription                = ")
-	outputFile.WriteString("\n|subscription gaming service = ")
-	outputFile.WriteString("\n}}")
+	progress.Step("Monetization")
+	output.WriteString("\n\n==Monetization==\n")
 
-	fmt.Println("* [12/25] Processing Microtransactions!")
+	output.WriteString(game.Data.Monetization.Template())
 
-	outputFile.WriteString("\n\n===Microtransactions===\n{{Microtransactions")
+	progress.Step("Microtransactions")
 
-	outputFile.WriteString("\n|boost               = ")
-	outputFile.WriteString("\n|cosmetic            = ")
-	outputFile.WriteString("\n|currency            = ")
-	outputFile.WriteString("\n|finite spend        = ")
-	outputFile.WriteString("\n|infinite spend      = ")
-	outputFile.WriteString("\n|free-to-grind       = ")
-	outputFile.WriteString("\n|loot box            = ")
-	outputFile.WriteString("\n|none                = ")
-	if !game.HasCategory(InAppPurchases) {
-		outputFile.WriteString("None")
-	}
-	outputFile.WriteString("\n|player trading      = ")
-	outputFile.WriteString("\n|time-limited        = ")
-	outputFile.WriteString("\n|unlock              = ")
-	outputFile.WriteString("\n}}")
+	output.WriteString("\n\n===Microtransactions===\n")
+	output.WriteString(game.Data.Microtransactions.Template())
 
-	fmt.Println("* [13/25] Processing DLCs!")
-	outputFile.WriteString("\n\n{{DLC|\n<!-- DLC rows goes below: -->\n}}")
+	progress.Step("DLC")
+	output.WriteString("\n\n{{DLC|\n<!-- DLC rows goes below: -->\n}}")
 
-	fmt.Println("* [14/25] Processing Config File Location!")
+	progress.Step("Configuration file location")
 
-	outputFile.WriteString("\n\n==Game data==\n===Configuration file(s) location===")
-	outputFile.WriteString("\n{{Game data|")
+	output.WriteString("\n\n==Game data==\n===Configuration file(s) location===")
+	output.WriteString("\n{{Game data|")
 	if game.Data.Platforms.Windows {
-		outputFile.WriteString("\n{{Game data/config|Windows|}}")
+		output.WriteString("\n{{Game data/config|Windows|}}")
 	}
 	if game.Data.Platforms.MAC {
-		outputFile.WriteString("\n{{Game data/config|OS X|}}")
+		output.WriteString("\n{{Game data/config|OS X|}}")
 	}
 	if game.Data.Platforms.Linux {
-		outputFile.WriteString("\n{{Game data/config|Linux|}}")
+		output.WriteString("\n{{Game data/config|Linux|}}")
 	}
-	outputFile.WriteString("\n}}")
+	output.WriteString("\n}}")
 
-	fmt.Println("* [15/25] Processing Save Game Location!")
+	progress.Step("Save game data location")
 
-	outputFile.WriteString("\n\n===Save game data location===")
-	outputFile.WriteString("\n{{Game data|")
+	output.WriteString("\n\n===Save game data location===")
+	output.WriteString("\n{{Game data|")
 	if game.Data.Platforms.Windows {
-		outputFile.WriteString("\n{{Game data/saves|Windows|}}")
+		output.WriteString("\n{{Game data/saves|Windows|}}")
 	}
 	if game.Data.Platforms.MAC {
-		outputFile.WriteString("\n{{Game data/saves|OS X|}}")
+		output.WriteString("\n{{Game data/saves|OS X|}}")
 	}
 	if game.Data.Platforms.Linux {
-		outputFile.WriteString("\n{{Game data/saves|Linux|}}")
+		output.WriteString("\n{{Game data/saves|Linux|}}")
 	}
-	outputFile.WriteString("\n}}")
+	output.WriteString("\n}}")
 
-	fmt.Println("* [16/25] Processing Save Game Sync!")
+	progress.Step("Save game cloud syncing")
 
-	outputFile.WriteString("\n\n===[[Glossary:Save game cloud syncing|Save game cloud syncing]]===\n{{Save game cloud syncing\n")
-	outputFile.WriteString(`|discord                   = 
+	output.WriteString("\n\n===[[Glossary:Save game cloud syncing|Save game cloud syncing]]===\n{{Save game cloud syncing\n")
+	output.WriteString(`|discord                   = 
 |discord notes             = 
 |epic games launcher       = 
 |epic games launcher notes = 
@@ -410,16 +538,16 @@ func main() {
 	// Otherwise, we can check if the game is out yet or not
 	// to determine whether we should add `unknown` or `false`
 	if game.HasCategory(SteamCloud) {
-		outputFile.WriteString("true")
+		output.WriteString("true")
 	} else {
 		if game.Data.ReleaseDate.ComingSoon {
-			outputFile.WriteString("unknown")
+			output.WriteString("unknown")
 		} else {
-			outputFile.WriteString("false")
+			output.WriteString("false")
 		}
 	}
 
-	outputFile.WriteString(`
+	output.WriteString(`
 |steam cloud notes         = 
 |ubisoft connect           = 
 |ubisoft connect notes     = 
@@ -428,9 +556,9 @@ func main() {
 }}`)
 
 	// TODO: Scan the description to search for widescreen, ray tracing etc support
-	fmt.Println("* [17/25] Processing Video!")
-	outputFile.WriteString("\n\n==Video==\n{{Video\n")
-	outputFile.WriteString(`|wsgf link                  = 
+	progress.Step("Video")
+	output.WriteString("\n\n==Video==\n{{Video\n")
+	output.WriteString(`|wsgf link                  = 
 |widescreen wsgf award      = 
 |multimonitor wsgf award    = 
 |ultrawidescreen wsgf award = 
@@ -470,16 +598,16 @@ func main() {
 |color blind notes          = 
 }}`)
 
-	fmt.Println("* [18/25] Processing Input!")
+	progress.Step("Input")
 
-	outputFile.WriteString("\n\n==Input==\n{{Input")
+	output.WriteString("\n\n==Input==\n{{Input")
 
 	controller := false
 	if game.Data.ControllerSupport != nil {
 		controller = true
 	}
 
-	outputFile.WriteString(`
+	output.WriteString(`
 |key remap                 = unknown
 |key remap notes           = 
 |acceleration option       = unknown
@@ -493,15 +621,15 @@ func main() {
 |touchscreen               = unknown
 |touchscreen notes         = `)
 
-	outputFile.WriteString(fmt.Sprintf("\n|controller support        = %v\n|controller support notes  = \n|full controller           = ", controller))
+	output.WriteString(fmt.Sprintf("\n|controller support        = %v\n|controller support notes  = \n|full controller           = ", controller))
 	if controller && *game.Data.ControllerSupport == "full" {
-		outputFile.WriteString("true")
+		output.WriteString("true")
 	} else {
-		outputFile.WriteString("false")
+		output.WriteString("false")
 	}
-	outputFile.WriteString("\n|full controller notes     = ")
+	output.WriteString("\n|full controller notes     = ")
 
-	outputFile.WriteString(`
+	output.WriteString(`
 |controller remap          = unknown
 |controller remap notes    = 
 |controller sensitivity    = unknown
@@ -548,20 +676,20 @@ func main() {
 |steam cursor detection notes = 
 }}`)
 
-	fmt.Println("* [19/25] Processing Audio!")
+	progress.Step("Audio")
 
 	game.ProcessLanguages()
 
-	outputFile.WriteString("\n\n")
-	outputFile.WriteString(`==Audio==
+	output.WriteString("\n\n")
+	output.WriteString(`==Audio==
 {{Audio
 |separate volume           = unknown
 |separate volume notes     = 
 |surround sound            = unknown
 |surround sound notes      = `)
-	outputFile.WriteString(fmt.Sprintf("\n|subtitles                 = %v\n", game.Data.Subtitles))
+	output.WriteString(fmt.Sprintf("\n|subtitles                 = %v\n", game.Data.Subtitles))
 
-	outputFile.WriteString(`|subtitles notes           = 
+	output.WriteString(`|subtitles notes           = 
 |closed captions           = unknown
 |closed captions notes     = 
 |mute on focus lost        = unknown
@@ -576,9 +704,9 @@ func main() {
 |general midi audio notes  = 
 }}`)
 
-	fmt.Println("* [20/25] Processing Languages!")
+	progress.Step("Languages")
 
-	outputFile.WriteString("\n\n{{L10n|content=")
+	output.WriteString("\n\n{{L10n|content=")
 
 	orderedLangauges := make([]string, 0, len(game.Data.Languages))
 	for key := range game.Data.Languages {
@@ -610,53 +738,53 @@ func main() {
 	}
 
 	for _, key := range orderedLangauges {
-		outputFile.WriteString(game.FormatLanguage(key))
+		output.WriteString(game.FormatLanguage(key))
 	}
 
-	outputFile.WriteString("\n}}\n")
+	output.WriteString("\n}}\n")
 
-	fmt.Println("* [21/25] Processing Network!")
+	progress.Step("Network")
 
 	if game.HasCategory(Multiplayer) {
-		outputFile.WriteString("\n\n==Network==")
-		outputFile.WriteString("\n{{Network/Multiplayer")
-		outputFile.WriteString("\n|local play           = ")
+		output.WriteString("\n\n==Network==")
+		output.WriteString("\n{{Network/Multiplayer")
+		output.WriteString("\n|local play           = ")
 		if game.HasCategory(LocalMultiPlayer) || game.HasCategory(LocalCoOp) {
-			outputFile.WriteString("true")
+			output.WriteString("true")
 		} else {
-			outputFile.WriteString("false")
+			output.WriteString("false")
 		}
-		outputFile.WriteString(`
+		output.WriteString(`
 |local play players   = 
 |local play modes     = 
 |local play notes     = `)
 
-		outputFile.WriteString("\n|lan play             = ")
+		output.WriteString("\n|lan play             = ")
 		if game.HasCategory(CoOp) {
-			outputFile.WriteString("true")
+			output.WriteString("true")
 		} else {
-			outputFile.WriteString("false")
+			output.WriteString("false")
 		}
-		outputFile.WriteString(`
+		output.WriteString(`
 |lan play players     = 
 |lan play modes       = 
 |lan play notes       = `)
 
-		outputFile.WriteString("\n|online play          = ")
+		output.WriteString("\n|online play          = ")
 		if game.HasCategory(OnlineMultiPlayer) || game.HasCategory(OnlineCoOp) {
-			outputFile.WriteString("true")
+			output.WriteString("true")
 		} else {
-			outputFile.WriteString("false")
+			output.WriteString("false")
 		}
-		outputFile.WriteString(`
+		output.WriteString(`
 |online play players  = 
 |online play modes    = 
 |online play notes    = 
 |asynchronous         = 
 |asynchronous notes   = 
 }}`)
-		outputFile.WriteString("\n{{Network/Connections")
-		outputFile.WriteString(`
+		output.WriteString("\n{{Network/Connections")
+		output.WriteString(`
 |matchmaking        = 
 |matchmaking notes  = 
 |p2p                = 
@@ -674,11 +802,11 @@ func main() {
 }}`)
 	}
 
-	fmt.Println("* [22/25] Processing API!")
+	progress.Step("API")
 
-	outputFile.WriteString("\n\n==Other information==\n===API===\n{{API\n")
-	outputFile.WriteString(fmt.Sprintf("|direct3d versions      = %s\n", game.FindDirectX()))
-	outputFile.WriteString(fmt.Sprintf(`|direct3d notes         = 
+	output.WriteString("\n\n==Other information==\n===API===\n{{API\n")
+	output.WriteString(fmt.Sprintf("|direct3d versions      = %s\n", game.FindDirectX()))
+	output.WriteString(fmt.Sprintf(`|direct3d notes         = 
 |directdraw versions    = 
 |directdraw notes       = 
 |wing                   = 
@@ -720,10 +848,10 @@ func main() {
 		GetExeBit(true, "mac", game.Data.Platforms, game.Data.MACRequirements), GetExeBit(false, "mac", game.Data.Platforms, game.Data.MACRequirements),
 		GetExeBit(true, "linux", game.Data.Platforms, game.Data.LinuxRequirements), GetExeBit(false, "linux", game.Data.Platforms, game.Data.LinuxRequirements)))
 
-	fmt.Println("* [23/25] Processing Middleware!")
+	progress.Step("Middleware")
 
-	outputFile.WriteString("\n\n===Middleware===\n{{Middleware")
-	outputFile.WriteString(`
+	output.WriteString("\n\n===Middleware===\n{{Middleware")
+	output.WriteString(`
 |physics          = 
 |physics notes    = 
 |audio            = 
@@ -740,15 +868,28 @@ func main() {
 |anticheat notes  = 
 }}`)
 
-	fmt.Println("* [24/25] Processing System Requirements!")
-	outputFile.WriteString("\n\n==System requirements==")
+	progress.Step("System requirements")
+	output.WriteString("\n\n==System requirements==")
 
-	outputFile.WriteString(game.OutputSpecs())
+	output.WriteString(game.OutputSpecs())
 
-	fmt.Println("* [25/25] Processing References!")
-	outputFile.WriteString("\n{{References}}")
+	progress.Step("References")
+	output.WriteString("\n{{References}}")
 
-	outputFile.Close()
+	article, err := game.AddReferences(output.String())
+	if err != nil {
+		Log.Warnf("Failed to add the references... (%s)", err)
+	}
+
+	// The stub is only added once it is known how much of the article was filled
+	completeness := ScoreArticle(article, AppConfig.Completeness)
+	completeness.Title = title.Title
+	game.Data.Completeness = &completeness
+	if completeness.Stub {
+		progress.Step("Stub")
+		Log.Debugf("Marked as a stub (completeness: %d/100)", completeness.Score)
+		article = "{{stub}}\n" + article
+	}
 
-	println(fmt.Sprintf("Successfully parsed information for game: '%s'", SanitiseName(game.Data.Name, true)))
+	return title.DisplayTitleLine() + article
 }
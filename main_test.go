@@ -0,0 +1,41 @@
+package main
+
+import (
+	"io"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	Log.Output = io.Discard
+
+	var err error
+	if StoreRegistry, err = LoadStores(""); err != nil {
+		panic(err)
+	}
+	if Schema, err = LoadSchema(""); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+// Runs the rest of the test in an empty temporary directory
+func chdirTemp(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func newTestGame(name string) Game {
+	var game Game
+	game.Success = true
+	game.Data.Name = name
+	game.Data.Ratings = make(map[string]Rating)
+	game.Data.Stores = make(map[string]Store)
+	return game
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var microtransactionTypes = []MonetizationType{
+	{Param: "boost", Taxonomy: "Boost"},
+	{Param: "cosmetic", Taxonomy: "Cosmetic"},
+	{Param: "currency", Taxonomy: "Currency"},
+	{Param: "finite spend", Taxonomy: "Finite spend"},
+	{Param: "infinite spend", Taxonomy: "Infinite spend"},
+	{Param: "free-to-grind", Taxonomy: "Free-to-grind"},
+	{Param: "loot box", Taxonomy: "Loot box"},
+	{Param: "none", Taxonomy: "None"},
+	{Param: "player trading", Taxonomy: "Player trading"},
+	{Param: "time-limited", Taxonomy: "Time-limited"},
+	{Param: "unlock", Taxonomy: "Unlock"},
+}
+
+// Currency, skins or boosters are just as often earned in play, so the
+// ambiguous words only count when a purchase is mentioned in the same sentence
+var microtransactionSignals = []MicrotransactionSignal{
+	{Param: "boost", Text: "Boosts can be purchased.", Pattern: `(?i)\b(xp boosts?|experience boosts?)\b|` + purchasable(`boosts?|boosters?|time ?savers?`)},
+	{Param: "cosmetic", Text: "Cosmetic items can be purchased.", Pattern: `(?i)` + purchasable(`cosmetics?|skins?|outfits?|costumes?`)},
+	{Param: "currency", Text: "In-game currency can be purchased.", Pattern: `(?i)\b(premium currency|virtual currency)\b|` + purchasable(`in-game currency|coins|gems|crystals|gold`)},
+	{Param: "loot box", Text: "Loot boxes can be purchased.", Pattern: `(?i)\b(loot ?box(es)?|loot crates?|gacha)\b|` + purchasable(`card packs?|chests?`)},
+	{Param: "player trading", Text: "Items can be traded between players.", Pattern: `(?i)\b(player trading|community market|trade items with other players)\b`},
+	{Param: "time-limited", Text: "Time-limited items or passes can be purchased.", Pattern: `(?i)\b(battle ?pass(es)?|limited[- ]time offers?)\b|` + purchasable(`limited[- ]time (items?|bundles?)`)},
+	{Param: "unlock", Text: "Content can be unlocked through purchases.", Pattern: `(?i)\b(season ?pass(es)?)\b|` + purchasable(`unlock (all|characters|levels|content|chapters)`)},
+}
+
+// Matches the items only when they are within one sentence of a purchase
+func purchasable(items string) string {
+	const purchase = `(buy|buying|bought|purchases?|purchased|purchasable|real[- ]money|microtransactions?|cash shop|premium shop)`
+	return `\b` + purchase + `\b[^.!?]{0,60}\b(` + items + `)\b|\b(` + items + `)\b[^.!?]{0,60}\b` + purchase + `\b`
+}
+
+// Finds the notice Steam shows in the purchase area of games with in-app
+// purchases, the "In-App Purchases" category label elsewhere on the page is
+// not a notice and is skipped
+func scrapeInAppNotice(page string) string {
+	doc, err := html.Parse(strings.NewReader(page))
+	if err != nil {
+		return ""
+	}
+
+	var notice string
+	var f func(n *html.Node)
+	f = func(n *html.Node) {
+		if len(notice) != 0 {
+			return
+		}
+
+		if n.Type == html.ElementNode {
+			for _, a := range n.Attr {
+				if a.Key != "class" || !strings.Contains(a.Val, "notice") {
+					continue
+				}
+
+				text := strings.Join(strings.Fields(nodeText(n)), " ")
+				if regexp.MustCompile(`(?i)in-app purchases?`).MatchString(text) {
+					notice = text
+					return
+				}
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			f(c)
+		}
+	}
+	f(doc)
+	return notice
+}
+
+// Joins the text of a node and all of its descendants
+func nodeText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+
+	var text string
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		text += nodeText(c) + " "
+	}
+	return text
+}
+
+// Scans the store page text, the app tags and the in-app purchases notice for
+// microtransaction signals, each detection notes the snippet that triggered it
+func (game *Game) DetectMicrotransactions() {
+	microtransactions := Microtransactions{
+		Text: make(map[string]string),
+	}
+
+	if !game.HasCategory(InAppPurchases) {
+		microtransactions.Text["none"] = "None"
+		game.Data.Microtransactions = microtransactions
+		return
+	}
+
+	sources := []struct{ name, text string }{
+		{"store page in-app purchases notice", game.Data.InAppNotice},
+		{"store page description", html.UnescapeString(RemoveTags(game.Data.AboutTheGame, " "))},
+		{"Steam tags", strings.Join(game.Data.AppTags, ", ")},
+	}
+
+	for _, signal := range microtransactionSignals {
+		re := regexp.MustCompile(signal.Pattern)
+		for _, source := range sources {
+			loc := re.FindStringIndex(source.text)
+			if loc == nil {
+				continue
+			}
+
+			note := fmt.Sprintf("Steam2PCGW found \"%s\" in the %s; this needs to be confirmed.", snippet(source.text, loc[0], loc[1]), source.name)
+			microtransactions.Text[signal.Param] = signal.Text + formatCitation(note)
+			break
+		}
+	}
+
+	game.Data.Microtransactions = microtransactions
+}
+
+// Cuts out a few words around the match so that reviewers can see the context
+func snippet(text string, start, end int) string {
+	const padding = 30
+
+	from := start - padding
+	if from <= 0 {
+		from = 0
+	} else if i := strings.Index(text[from:start], " "); i != -1 {
+		from += i + 1
+	}
+
+	to := end + padding
+	if to >= len(text) {
+		to = len(text)
+	} else if i := strings.LastIndex(text[end:to], " "); i != -1 {
+		to = end + i
+	}
+
+	output := strings.Join(strings.Fields(text[from:to]), " ")
+	output = strings.ToValidUTF8(output, "")
+	output = strings.ReplaceAll(output, "|", "/")
+	output = strings.ReplaceAll(output, "}}", "")
+	return output
+}
+
+func (microtransactions *Microtransactions) Taxonomy() string {
+	var values []string
+	for _, v := range microtransactionTypes {
+		if _, ok := microtransactions.Text[v.Param]; ok {
+			values = append(values, v.Taxonomy)
+		}
+	}
+	return strings.Join(values, ", ")
+}
+
+func (microtransactions *Microtransactions) Template() string {
+	output := "{{Microtransactions"
+	for _, v := range microtransactionTypes {
+		output += fmt.Sprintf("\n|%-20s= %s", v.Param, microtransactions.Text[v.Param])
+	}
+	output += "\n}}"
+	return output
+}
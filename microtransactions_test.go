@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectMicrotransactions(t *testing.T) {
+	tests := []struct {
+		description string
+		want        string
+	}{
+		{"Buy gems to speed up construction.", "Currency"},
+		{"Coins can be purchased with real money.", "Currency"},
+		{"Cosmetic skins are available for purchase in the store.", "Cosmetic"},
+		{"Purchase the battle pass to earn exclusive rewards.", "Time-limited"},
+		{"Open loot boxes for a chance at rare items.", "Loot box"},
+		{"Buy XP boosts to level up faster.", "Boost"},
+		{"Sell your items on the Steam Community Market.", "Player trading"},
+		{"Collect coins and gems to unlock new levels.", ""},
+		{"Earn dozens of skins and outfits by playing.", ""},
+		{"Trade at the marketplace in town. Unlock characters as you progress.", ""},
+		{"Buy the game once. Collect crystals to power your ship.", ""},
+	}
+
+	for _, test := range tests {
+		game := newTestGame("Portal 2")
+		game.Data.Categories = []Category{{ID: int64(InAppPurchases)}}
+		game.Data.AboutTheGame = test.description
+		game.DetectMicrotransactions()
+
+		if got := game.Data.Microtransactions.Taxonomy(); got != test.want {
+			t.Errorf("%q: taxonomy = %q, want %q", test.description, got, test.want)
+		}
+	}
+}
+
+func TestDetectMicrotransactionsNone(t *testing.T) {
+	game := newTestGame("Portal 2")
+	game.Data.AboutTheGame = "Buy gems to speed up construction."
+	game.DetectMicrotransactions()
+
+	if got := game.Data.Microtransactions.Taxonomy(); got != "None" {
+		t.Errorf("taxonomy without in-app purchases = %q, want None", got)
+	}
+}
+
+func TestDetectMicrotransactionsNotice(t *testing.T) {
+	game := newTestGame("Portal 2")
+	game.Data.Categories = []Category{{ID: int64(InAppPurchases)}}
+	game.Data.InAppNotice = "Includes in-app purchases of premium currency"
+	game.DetectMicrotransactions()
+
+	text := game.Data.Microtransactions.Text["currency"]
+	if !strings.Contains(text, "in the store page in-app purchases notice") {
+		t.Errorf("currency = %q, want it found in the notice", text)
+	}
+}
+
+func TestScrapeInAppNotice(t *testing.T) {
+	label := `<div class="game_area_details_specs"><a class="name" href="https://store.steampowered.com/search/?category2=35">In-App Purchases</a></div>`
+
+	tests := []struct {
+		name, page, want string
+	}{
+		{"notice", label + `<div class="game_area_purchase_game_notice">
+			This game includes <b>in-app purchases</b> of premium currency.
+		</div>`, "This game includes in-app purchases of premium currency."},
+		{"category label only", label, ""},
+		{"other notice", `<div class="notice_box_content">This game is not yet available.</div>` + label, ""},
+	}
+
+	for _, test := range tests {
+		if got := scrapeInAppNotice(test.page); got != test.want {
+			t.Errorf("%s: notice = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
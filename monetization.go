@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var monetizationTypes = []MonetizationType{
+	{Param: "ad-supported", Taxonomy: "Ad-supported"},
+	{Param: "dlc", Taxonomy: "DLC"},
+	{Param: "expansion pack", Taxonomy: "Expansion pack"},
+	{Param: "freeware", Taxonomy: "Freeware"},
+	{Param: "free-to-play", Taxonomy: "Free-to-play"},
+	{Param: "one-time game purchase", Taxonomy: "One-time game purchase"},
+	{Param: "sponsored", Taxonomy: "Sponsored"},
+	{Param: "subscription", Taxonomy: "Subscription"},
+	{Param: "subscription gaming service", Taxonomy: "Subscription gaming service"},
+}
+
+func (game *Game) InferMonetization() {
+	monetization := Monetization{
+		Text:     make(map[string]string),
+		Comments: make(map[string]string),
+	}
+
+	subscription := false
+	freeSubs, paidSubs, expansions := 0, 0, 0
+	for _, group := range game.Data.PackageGroups {
+		if strings.EqualFold(group.IsRecurringSubscription, "true") {
+			subscription = true
+		}
+
+		for _, sub := range group.Subs {
+			if sub.IsFreeLicense || sub.CanGetFreeLicense == "1" {
+				freeSubs++
+			} else {
+				paidSubs++
+			}
+
+			if strings.Contains(strings.ToLower(sub.OptionText), "expansion") {
+				expansions++
+			}
+		}
+	}
+
+	if subscription {
+		monetization.Text["subscription"] = "The game requires a recurring subscription to access."
+	}
+
+	if game.Data.IsFree {
+		if game.HasCategory(InAppPurchases) || len(game.Data.Dlc) != 0 {
+			monetization.Text["free-to-play"] = "The game is free to play, with optional paid content."
+		} else {
+			monetization.Text["freeware"] = "The game is available for free."
+			monetization.Comments["freeware"] = "No in-app purchases or DLC were found on Steam; confirm the game is freeware rather than free-to-play"
+		}
+	} else if !subscription {
+		monetization.Text["one-time game purchase"] = "The game requires an upfront purchase to access."
+		if paidSubs == 0 && freeSubs != 0 {
+			monetization.Comments["one-time game purchase"] = "Every Steam package is a free license; the game may actually be free"
+		} else if freeSubs != 0 {
+			monetization.Text["one-time game purchase"] += " A free license (such as a demo) is also available."
+		} else if len(game.Data.Packages) == 0 && !game.Data.ReleaseDate.ComingSoon {
+			monetization.Comments["one-time game purchase"] = "No Steam packages were found; the game may no longer be sold"
+		}
+	}
+
+	if services := game.SubscriptionServices(); len(services) != 0 {
+		monetization.Text["subscription gaming service"] = fmt.Sprintf("The game is available via %s.", joinList(services))
+	}
+
+	if len(game.Data.Dlc) != 0 {
+		monetization.Text["dlc"] = fmt.Sprintf("The game has %d DLC available on Steam.", len(game.Data.Dlc))
+		if expansions == 0 {
+			monetization.Comments["expansion pack"] = "Check whether any of the DLC are expansion packs"
+		}
+	}
+
+	if expansions != 0 {
+		monetization.Text["expansion pack"] = "The game has expansion packs available on Steam."
+	}
+
+	adsRe := regexp.MustCompile(`(?i)\b(ad-supported|advertisements?|in-game ads)\b`)
+	if adsRe.MatchString(game.Data.AboutTheGame) || adsRe.MatchString(game.Data.DetailedDescription) {
+		monetization.Comments["ad-supported"] = "The store description mentions advertisements; confirm whether the game is ad-supported"
+	}
+
+	game.Data.Monetization = monetization
+}
+
+// Same conclusions as the Monetization template, formatted for the taxonomy row
+func (monetization *Monetization) Taxonomy() string {
+	var values, comments []string
+	for _, v := range monetizationTypes {
+		if _, ok := monetization.Text[v.Param]; ok {
+			values = append(values, v.Taxonomy)
+		}
+
+		if comment, ok := monetization.Comments[v.Param]; ok {
+			comments = append(comments, formatComment(v.Taxonomy+"? "+comment))
+		}
+	}
+
+	output := strings.Join(values, ", ")
+	if len(comments) != 0 {
+		output += " " + strings.Join(comments, " ")
+	}
+	return strings.TrimSpace(output)
+}
+
+func (monetization *Monetization) Template() string {
+	output := "{{Monetization"
+	for _, v := range monetizationTypes {
+		output += fmt.Sprintf("\n|%-28s= %s", v.Param, monetization.Text[v.Param])
+		if comment, ok := monetization.Comments[v.Param]; ok {
+			output += formatComment(comment)
+		}
+	}
+	output += "\n}}"
+	return output
+}
+
+func formatComment(note string) string {
+	return "<!-- " + note + " -->"
+}
+
+// Joins a list as "a, b and c"
+func joinList(list []string) string {
+	if len(list) < 2 {
+		return strings.Join(list, "")
+	}
+	return strings.Join(list[:len(list)-1], ", ") + " and " + list[len(list)-1]
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInferMonetization(t *testing.T) {
+	paid := PackageGroup{Subs: []Sub{{PackageID: 1, OptionText: "Portal 2 - $9.99"}}}
+	demo := Sub{PackageID: 2, OptionText: "Demo", IsFreeLicense: true}
+
+	tests := []struct {
+		name     string
+		setup    func(game *Game)
+		want     string // Taxonomy without the comments
+		comments []string
+	}{
+		{"paid", func(game *Game) {
+			game.Data.PackageGroups = []PackageGroup{paid}
+			game.Data.Packages = []int64{1}
+		}, "One-time game purchase", nil},
+		{"paid with a free license", func(game *Game) {
+			game.Data.PackageGroups = []PackageGroup{{Subs: append(paid.Subs, demo)}}
+			game.Data.Packages = []int64{1, 2}
+		}, "One-time game purchase", nil},
+		{"only free licenses", func(game *Game) {
+			game.Data.PackageGroups = []PackageGroup{{Subs: []Sub{demo}}}
+			game.Data.Packages = []int64{2}
+		}, "One-time game purchase", []string{"one-time game purchase"}},
+		{"no longer sold", func(game *Game) {}, "One-time game purchase", []string{"one-time game purchase"}},
+		{"free-to-play with in-app purchases", func(game *Game) {
+			game.Data.IsFree = true
+			game.Data.Categories = []Category{{ID: int64(InAppPurchases)}}
+		}, "Free-to-play", nil},
+		{"free-to-play with DLC", func(game *Game) {
+			game.Data.IsFree = true
+			game.Data.Dlc = []int64{10, 11}
+		}, "DLC, Free-to-play", []string{"expansion pack"}},
+		{"freeware", func(game *Game) {
+			game.Data.IsFree = true
+		}, "Freeware", []string{"freeware"}},
+		{"subscription", func(game *Game) {
+			game.Data.PackageGroups = []PackageGroup{{IsRecurringSubscription: "true", Subs: paid.Subs}}
+		}, "Subscription", nil},
+		{"expansion packs", func(game *Game) {
+			game.Data.PackageGroups = []PackageGroup{{Subs: append(paid.Subs, Sub{PackageID: 3, OptionText: "Expansion Pass"})}}
+			game.Data.Packages = []int64{1, 3}
+			game.Data.Dlc = []int64{10}
+		}, "DLC, Expansion pack, One-time game purchase", nil},
+		{"subscription gaming service", func(game *Game) {
+			game.Data.PackageGroups = []PackageGroup{paid}
+			game.Data.Packages = []int64{1}
+			game.Data.Subscriptions = []Subscription{{Service: SubscriptionService{Name: "PC Game Pass"}}}
+		}, "One-time game purchase, Subscription gaming service", nil},
+		{"advertisements", func(game *Game) {
+			game.Data.IsFree = true
+			game.Data.Categories = []Category{{ID: int64(InAppPurchases)}}
+			game.Data.AboutTheGame = "Watch advertisements to earn rewards."
+		}, "Free-to-play", []string{"ad-supported"}},
+	}
+
+	for _, test := range tests {
+		game := newTestGame("Portal 2")
+		test.setup(&game)
+		game.InferMonetization()
+
+		var values []string
+		for _, v := range monetizationTypes {
+			if _, ok := game.Data.Monetization.Text[v.Param]; ok {
+				values = append(values, v.Taxonomy)
+			}
+		}
+		if got := strings.Join(values, ", "); got != test.want {
+			t.Errorf("%s: taxonomy = %q, want %q", test.name, got, test.want)
+		}
+
+		if len(game.Data.Monetization.Comments) != len(test.comments) {
+			t.Errorf("%s: comments = %v, want on %q", test.name, game.Data.Monetization.Comments, test.comments)
+		}
+		for _, param := range test.comments {
+			if _, ok := game.Data.Monetization.Comments[param]; !ok {
+				t.Errorf("%s: no comment on '%s', comments: %v", test.name, param, game.Data.Monetization.Comments)
+			}
+		}
+	}
+}
+
+func TestMonetizationTaxonomy(t *testing.T) {
+	monetization := Monetization{
+		Text:     map[string]string{"dlc": "x", "free-to-play": "x"},
+		Comments: map[string]string{"expansion pack": "Check the DLC"},
+	}
+	if got, want := monetization.Taxonomy(), "DLC, Free-to-play <!-- Expansion pack? Check the DLC -->"; got != want {
+		t.Errorf("Taxonomy() = %q, want %q", got, want)
+	}
+}
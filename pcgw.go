@@ -0,0 +1,345 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type PCGWClient struct {
+	APIURL string
+	Client *http.Client
+}
+
+func NewPCGWClient(config PCGWConfig) *PCGWClient {
+	// The login session is kept in cookies
+	jar, _ := cookiejar.New(nil)
+	return &PCGWClient{
+		APIURL: config.APIURL,
+		Client: &http.Client{Jar: jar},
+	}
+}
+
+func (client *PCGWClient) get(params url.Values, result interface{}) error {
+	params.Set("format", "json")
+
+	req, err := http.NewRequest("GET", client.APIURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	return client.do(req, result)
+}
+
+func (client *PCGWClient) post(params url.Values, result interface{}) error {
+	params.Set("format", "json")
+
+	req, err := http.NewRequest("POST", client.APIURL, strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return client.do(req, result)
+}
+
+// Posts the params along with a file as multipart/form-data
+func (client *PCGWClient) postFile(params url.Values, fileName string, file []byte, result interface{}) error {
+	params.Set("format", "json")
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for key := range params {
+		if err := writer.WriteField(key, params.Get(key)); err != nil {
+			return err
+		}
+	}
+
+	part, err := writer.CreateFormFile("file", fileName)
+	if err != nil {
+		return err
+	}
+	if _, err = part.Write(file); err != nil {
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", client.APIURL, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return client.do(req, result)
+}
+
+func (client *PCGWClient) do(req *http.Request, result interface{}) error {
+	req.Header.Set("User-Agent", fmt.Sprintf("%s/%s (%s)", strings.ReplaceAll(APP_NAME, " ", ""), VERSION, GH_LINK))
+
+	response, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("'%s' returned HTTP code %d", client.APIURL, response.StatusCode)
+	}
+
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+// Pages whose infobox lists the app ID, either as its steam appid or as one
+// of its steam appid side
+func (client *PCGWClient) FindBySteamID(appId string) (pages []string, err error) {
+	if !regexp.MustCompile(`^\d+$`).MatchString(appId) {
+		return nil, fmt.Errorf("invalid Steam app ID '%s'", appId)
+	}
+
+	params := url.Values{
+		"action": {"cargoquery"},
+		"tables": {"Infobox_game"},
+		"fields": {"Infobox_game._pageName=Page"},
+		"where":  {fmt.Sprintf(`Infobox_game.Steam_AppID HOLDS "%s"`, appId)},
+		"limit":  {"10"},
+	}
+
+	var result PCGWCargoQuery
+	if err = client.get(params, &result); err != nil {
+		return
+	}
+
+	for _, row := range result.CargoQuery {
+		pages = appendUnique(pages, row.Title["Page"])
+	}
+	return
+}
+
+// Returns the page title the given title resolves to (following redirects),
+// or an empty string if there is no such page
+func (client *PCGWClient) FindByTitle(title string) (string, error) {
+	params := url.Values{
+		"action":    {"query"},
+		"titles":    {title},
+		"redirects": {"1"},
+	}
+
+	var result PCGWQuery
+	if err := client.get(params, &result); err != nil {
+		return "", err
+	}
+
+	for _, page := range result.Query.Pages {
+		if page.Missing == nil && page.Invalid == nil {
+			return page.Title, nil
+		}
+	}
+	return "", nil
+}
+
+// Existing articles matching either the app ID or the title
+func (client *PCGWClient) FindExisting(appId, title string) (pages []string, err error) {
+	if pages, err = client.FindBySteamID(appId); err != nil {
+		return
+	}
+
+	page, err := client.FindByTitle(title)
+	if err != nil {
+		return
+	}
+
+	if len(page) != 0 {
+		pages = appendUnique(pages, page)
+	}
+	return
+}
+
+// Current wikitext of the page
+func (client *PCGWClient) Wikitext(title string) (string, error) {
+	params := url.Values{
+		"action":        {"query"},
+		"prop":          {"revisions"},
+		"rvprop":        {"content"},
+		"rvslots":       {"main"},
+		"titles":        {title},
+		"redirects":     {"1"},
+		"formatversion": {"2"},
+	}
+
+	var result PCGWRevisionQuery
+	if err := client.get(params, &result); err != nil {
+		return "", err
+	}
+
+	for _, page := range result.Query.Pages {
+		if page.Missing || len(page.Revisions) == 0 {
+			continue
+		}
+		return page.Revisions[0].Slots.Main.Content, nil
+	}
+	return "", fmt.Errorf("page '%s' does not exist", title)
+}
+
+func apiError(err *PCGWAPIError) error {
+	return fmt.Errorf("%s (%s)", err.Info, err.Code)
+}
+
+func (client *PCGWClient) token(kind string) (string, error) {
+	var result PCGWTokens
+	if err := client.get(url.Values{"action": {"query"}, "meta": {"tokens"}, "type": {kind}}, &result); err != nil {
+		return "", err
+	} else if result.Error != nil {
+		return "", apiError(result.Error)
+	}
+
+	if kind == "login" {
+		return result.Query.Tokens.LoginToken, nil
+	}
+	return result.Query.Tokens.CSRFToken, nil
+}
+
+// Logs in with a bot password (Special:BotPasswords)
+func (client *PCGWClient) Login(username, password string) error {
+	if len(username) == 0 || len(password) == 0 {
+		return errors.New("no bot username or password is set in the config")
+	}
+
+	token, err := client.token("login")
+	if err != nil {
+		return err
+	}
+
+	var result PCGWLogin
+	params := url.Values{
+		"action":     {"login"},
+		"lgname":     {username},
+		"lgpassword": {password},
+		"lgtoken":    {token},
+	}
+	if err = client.post(params, &result); err != nil {
+		return err
+	} else if result.Error != nil {
+		return apiError(result.Error)
+	}
+
+	if result.Login.Result != "Success" {
+		return fmt.Errorf("login failed: %s %s", result.Login.Result, result.Login.Reason)
+	}
+	return nil
+}
+
+// Saves the page, which has to be new unless overwrite is set
+func (client *PCGWClient) Edit(title, text, summary string, overwrite bool) (revision int64, err error) {
+	token, err := client.token("csrf")
+	if err != nil {
+		return
+	}
+
+	params := url.Values{
+		"action":  {"edit"},
+		"title":   {title},
+		"text":    {text},
+		"summary": {summary},
+		"bot":     {"1"},
+		"token":   {token},
+	}
+	if !overwrite {
+		params.Set("createonly", "1")
+	}
+
+	var result PCGWEdit
+	if err = client.post(params, &result); err != nil {
+		return
+	} else if result.Error != nil {
+		err = apiError(result.Error)
+		return
+	}
+
+	if result.Edit.Result != "Success" {
+		err = fmt.Errorf("edit failed: %s", result.Edit.Result)
+		return
+	}
+	return result.Edit.NewRevID, nil
+}
+
+// Uploads the file, existing files are never replaced and any warning is
+// returned instead
+func (client *PCGWClient) Upload(fileName string, file []byte, description, summary string) (warnings []string, err error) {
+	token, err := client.token("csrf")
+	if err != nil {
+		return
+	}
+
+	params := url.Values{
+		"action":   {"upload"},
+		"filename": {fileName},
+		"text":     {description},
+		"comment":  {summary},
+		"token":    {token},
+	}
+
+	var result PCGWUpload
+	if err = client.postFile(params, fileName, file, &result); err != nil {
+		return
+	} else if result.Error != nil {
+		err = apiError(result.Error)
+		return
+	}
+
+	for warning := range result.Upload.Warnings {
+		warnings = append(warnings, warning)
+	}
+	sort.Strings(warnings)
+
+	if result.Upload.Result != "Success" && len(warnings) == 0 {
+		err = fmt.Errorf("upload failed: %s", result.Upload.Result)
+	}
+	return
+}
+
+// Names of every page of the Company namespace, without the namespace prefix
+func (client *PCGWClient) Companies() (companies []string, err error) {
+	var namespaces PCGWNamespaces
+	if err = client.get(url.Values{"action": {"query"}, "meta": {"siteinfo"}, "siprop": {"namespaces"}}, &namespaces); err != nil {
+		return
+	}
+
+	namespace := -1
+	for _, v := range namespaces.Query.Namespaces {
+		if v.Canonical == "Company" || v.Name == "Company" {
+			namespace = v.ID
+		}
+	}
+	if namespace == -1 {
+		return nil, errors.New("the wiki has no Company namespace")
+	}
+
+	params := url.Values{
+		"action":      {"query"},
+		"list":        {"allpages"},
+		"apnamespace": {fmt.Sprint(namespace)},
+		"aplimit":     {"max"},
+	}
+	for {
+		var result PCGWAllPages
+		if err = client.get(params, &result); err != nil {
+			return
+		}
+
+		for _, page := range result.Query.AllPages {
+			companies = append(companies, strings.TrimPrefix(page.Title, "Company:"))
+		}
+
+		if len(result.Continue["apcontinue"]) == 0 {
+			return
+		}
+		params.Set("apcontinue", result.Continue["apcontinue"])
+	}
+}
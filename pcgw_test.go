@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Stand-in for the PCGW lookups: app 620 is listed by "Portal 2", which
+// "Portal II" redirects to
+func newPCGWLookupStandIn(t *testing.T) *PCGWClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		response := map[string]interface{}{}
+
+		switch query.Get("action") {
+		case "cargoquery":
+			if query.Get("tables") != "Infobox_game" || query.Get("fields") != "Infobox_game._pageName=Page" {
+				http.Error(w, "unexpected query", http.StatusBadRequest)
+				return
+			}
+
+			rows := []interface{}{}
+			if query.Get("where") == `Infobox_game.Steam_AppID HOLDS "620"` {
+				rows = append(rows, map[string]interface{}{"title": map[string]string{"Page": "Portal 2"}})
+			}
+			response["cargoquery"] = rows
+		case "query":
+			title := query.Get("titles")
+			if title == "Portal II" && query.Get("redirects") == "1" {
+				response["query"] = map[string]interface{}{
+					"redirects": []map[string]string{{"from": "Portal II", "to": "Portal 2"}},
+					"pages":     map[string]interface{}{"1": map[string]interface{}{"pageid": 1, "title": "Portal 2"}},
+				}
+				break
+			}
+
+			page := map[string]interface{}{"title": title, "missing": ""}
+			if title == "Portal 2" {
+				page = map[string]interface{}{"pageid": 1, "title": title}
+			}
+			response["query"] = map[string]interface{}{"pages": map[string]interface{}{"-1": page}}
+		default:
+			http.Error(w, "unknown action", http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return NewPCGWClient(PCGWConfig{APIURL: server.URL})
+}
+
+func TestFindBySteamID(t *testing.T) {
+	client := newPCGWLookupStandIn(t)
+
+	pages, err := client.FindBySteamID("620")
+	if err != nil || strings.Join(pages, ", ") != "Portal 2" {
+		t.Errorf("FindBySteamID(620) = %v, %v, want Portal 2", pages, err)
+	}
+
+	if pages, err = client.FindBySteamID("400"); err != nil || len(pages) != 0 {
+		t.Errorf("FindBySteamID(400) = %v, %v, want no pages", pages, err)
+	}
+
+	if _, err = client.FindBySteamID(`620" OR "1`); err == nil {
+		t.Error("an invalid app ID was queried")
+	}
+}
+
+func TestFindByTitle(t *testing.T) {
+	client := newPCGWLookupStandIn(t)
+
+	for title, want := range map[string]string{
+		"Portal 2":  "Portal 2",
+		"Portal II": "Portal 2",
+		"Portal 3":  "",
+	} {
+		if got, err := client.FindByTitle(title); err != nil || got != want {
+			t.Errorf("FindByTitle(%q) = %q, %v, want %q", title, got, err, want)
+		}
+	}
+}
+
+func TestFindExisting(t *testing.T) {
+	client := newPCGWLookupStandIn(t)
+
+	tests := []struct {
+		appId, title, want string
+	}{
+		{"620", "Portal 2", "Portal 2"},
+		{"620", "Portal II", "Portal 2"},
+		{"400", "Portal II", "Portal 2"},
+		{"620", "Portal 3", "Portal 2"},
+		{"400", "Portal 3", ""},
+	}
+
+	for _, test := range tests {
+		pages, err := client.FindExisting(test.appId, test.title)
+		if err != nil || strings.Join(pages, ", ") != test.want {
+			t.Errorf("FindExisting(%s, %q) = %v, %v, want %q", test.appId, test.title, pages, err, test.want)
+		}
+	}
+}
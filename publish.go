@@ -0,0 +1,190 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Publishes a generated (and reviewed) article. Every run previews what would
+// be done, nothing is written to the wiki without -confirm, which also
+// requires the article to be unchanged since a previous preview
+func RunPublish(args []string) {
+	if code := publishArticle(args); code != 0 {
+		os.Exit(code)
+	}
+}
+
+// Exit code 1 if publishing failed or was refused, 2 on invalid arguments
+func publishArticle(args []string) int {
+	flags := flag.NewFlagSet("publish", flag.ContinueOnError)
+	confirm := flags.Bool("confirm", false, "publish the article previewed by the last dry run")
+	overwrite := flags.Bool("overwrite", false, "replace the page if it already exists")
+	coverFile := flags.String("cover", "", "upload this file as the cover instead of the Steam library artwork")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: publish [-confirm] [-overwrite] [-cover file] <appid>")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return 0
+	} else if err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	gameId, err := ResolveAppID(flags.Arg(0), false)
+	if err != nil {
+		Log.Errorf("%s", err)
+		return 1
+	}
+	article, err := os.ReadFile(fmt.Sprintf("output/%s.txt", gameId))
+	if err != nil {
+		Log.Errorf("Failed to read the article, generate and review it first... (%s)", err)
+		return 1
+	}
+
+	Log.SetField("app", gameId)
+	pageTitle, err := cachedTitle(gameId)
+	if err != nil {
+		Log.Errorf("Failed to read the title from the cached app details... (%s)", err)
+		return 1
+	}
+
+	title := pageTitle.Title
+	coverName := title + " cover.jpg"
+	summary := editSummary()
+
+	cover, coverSource, err := readCover(gameId, *coverFile)
+	if err != nil {
+		Log.Warnf("Failed to get the cover, it will not be uploaded... (%s)", err)
+	}
+
+	client := NewPCGWClient(AppConfig.PCGW)
+	existing, err := client.FindByTitle(title)
+	if err != nil {
+		Log.Errorf("Failed to check whether the page exists... (%s)", err)
+		return 1
+	}
+
+	fmt.Printf("Dry run of publishing to %s\n", AppConfig.PCGW.APIURL)
+	switch {
+	case len(existing) == 0:
+		fmt.Printf("Page:    %s (new page)\n", title)
+	case *overwrite:
+		fmt.Printf("Page:    %s (exists as '%s', it will be overwritten)\n", title, existing)
+	default:
+		fmt.Printf("Page:    %s (exists as '%s', it will NOT be overwritten)\n", title, existing)
+	}
+	fmt.Printf("Text:    output/%s.txt (%d bytes)\n", gameId, len(article))
+	if cover != nil {
+		fmt.Printf("Cover:   File:%s from %s (%d bytes)\n", coverName, coverSource, len(cover))
+	}
+	fmt.Printf("Summary: %s\n", summary)
+
+	previewFile := fmt.Sprintf("output/%s.preview", gameId)
+	checksum := sha256.Sum256(article)
+	hash := hex.EncodeToString(checksum[:])
+
+	if !*confirm {
+		if err = os.WriteFile(previewFile, []byte(hash), 0777); err != nil {
+			Log.Errorf("Failed to record the dry run...")
+			return 1
+		}
+		Log.Infof("Nothing was published, run 'publish -confirm %s' to publish it", gameId)
+		return 0
+	}
+
+	if previewed, err := os.ReadFile(previewFile); err != nil || strings.TrimSpace(string(previewed)) != hash {
+		Log.Errorf("The article was not previewed or changed since the last dry run, run publish without -confirm first... Process stopped!")
+		return 1
+	}
+
+	if len(existing) != 0 && !*overwrite {
+		Log.Errorf("The page already exists, pass -overwrite to replace it... Process stopped!")
+		return 1
+	}
+
+	if err = client.Login(AppConfig.PCGW.BotUsername, AppConfig.PCGW.BotPassword); err != nil {
+		Log.Errorf("Failed to log in... (%s)", err)
+		return 1
+	}
+
+	revision, err := client.Edit(title, string(article), summary, *overwrite)
+	if err != nil {
+		Log.Errorf("Failed to publish the article... (%s)", err)
+		return 1
+	}
+	Log.Infof("Published '%s' (revision %d)", title, revision)
+	os.Remove(previewFile)
+
+	if cover == nil {
+		return 0
+	}
+
+	description := fmt.Sprintf("Cover of [[%s]].", title)
+	if len(*coverFile) == 0 {
+		description += " Source: " + coverSource
+	}
+	warnings, err := client.Upload(coverName, cover, description, summary)
+	if err != nil {
+		Log.Warnf("Failed to upload the cover... (%s)", err)
+	} else if len(warnings) != 0 {
+		Log.Warnf("The cover was not uploaded... (warnings: %s)", strings.Join(warnings, ", "))
+	} else {
+		Log.Infof("Uploaded File:%s", coverName)
+	}
+	return 0
+}
+
+// The title of the game, from the cached app details the article was generated
+// from. The other sources are not needed for it, so they are not fetched again
+func cachedTitle(gameId string) (title PageTitle, err error) {
+	data, err := os.ReadFile(fmt.Sprintf("cache/%s.json", gameId))
+	if err != nil {
+		return
+	}
+
+	var details map[string]Game
+	if err = json.Unmarshal(data, &details); err != nil {
+		return
+	}
+
+	game, ok := details[gameId]
+	if !ok || !game.Success || len(game.Data.Name) == 0 {
+		err = fmt.Errorf("the cached app details have no store page for %s", gameId)
+		return
+	}
+	return game.Title(), nil
+}
+
+// The cover file given on the command line, or the Steam library artwork
+func readCover(gameId, fileName string) (cover []byte, source string, err error) {
+	if len(fileName) != 0 {
+		cover, err = os.ReadFile(fileName)
+		return cover, fileName, err
+	}
+
+	source = fmt.Sprintf(COVER_LINK, gameId)
+	response, err := makeRequest(source)
+	if err = checkRequest(response, err); err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	cover, err = io.ReadAll(response.Body)
+	return
+}
+
+func editSummary() string {
+	return fmt.Sprintf("Created with steam2pcgw %s (%s)", VERSION, GH_LINK)
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+// Stand-in for the PCGW API, recording the edits and uploads made
+type pcgwStandIn struct {
+	*httptest.Server
+	existing map[string]bool // Titles of the pages which exist
+	edits    []map[string]string
+	uploads  []string
+}
+
+func newPCGWStandIn(t *testing.T, existing ...string) *pcgwStandIn {
+	standIn := &pcgwStandIn{existing: make(map[string]bool)}
+	for _, title := range existing {
+		standIn.existing[title] = true
+	}
+
+	standIn.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseMultipartForm(1 << 20)
+		response := map[string]interface{}{}
+
+		switch action := r.FormValue("action"); {
+		case action == "query" && len(r.FormValue("meta")) != 0:
+			response["query"] = map[string]interface{}{"tokens": map[string]string{"logintoken": "login+\\", "csrftoken": "csrf+\\"}}
+		case action == "query":
+			title := r.FormValue("titles")
+			page := map[string]interface{}{"title": title}
+			if !standIn.existing[title] {
+				page["missing"] = ""
+			}
+			response["query"] = map[string]interface{}{"pages": map[string]interface{}{"-1": page}}
+		case action == "login":
+			response["login"] = map[string]string{"result": "Success"}
+		case action == "edit":
+			edit := map[string]string{"title": r.FormValue("title"), "text": r.FormValue("text"), "createonly": r.FormValue("createonly")}
+			standIn.edits = append(standIn.edits, edit)
+			if standIn.existing[edit["title"]] && len(edit["createonly"]) != 0 {
+				response["error"] = PCGWAPIError{Code: "articleexists", Info: "The article you tried to create has been created already."}
+				break
+			}
+			standIn.existing[edit["title"]] = true
+			response["edit"] = map[string]interface{}{"result": "Success", "title": edit["title"], "newrevid": 100 + len(standIn.edits)}
+		case action == "upload":
+			standIn.uploads = append(standIn.uploads, r.FormValue("filename"))
+			response["upload"] = map[string]string{"result": "Success", "filename": r.FormValue("filename")}
+		default:
+			http.Error(w, "unknown action", http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(standIn.Close)
+	return standIn
+}
+
+// Runs the test in a directory holding the cached app details and the
+// generated article of app 620, publishing to the stand-in
+func setupPublish(t *testing.T, standIn *pcgwStandIn) {
+	chdirTemp(t)
+
+	config := AppConfig
+	AppConfig.PCGW = PCGWConfig{APIURL: standIn.URL, BotUsername: "bot", BotPassword: "password"}
+	t.Cleanup(func() { AppConfig = config })
+
+	os.Mkdir("cache", 0777)
+	os.Mkdir("output", 0777)
+	files := map[string]string{
+		"cache/620.json": `{"620":{"success":true,"data":{"name":"Portal 2","steam_appid":620}}}`,
+		"output/620.txt": "{{Infobox game}}\n",
+		"cover.jpg":      "cover",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPublishDryRun(t *testing.T) {
+	standIn := newPCGWStandIn(t)
+	setupPublish(t, standIn)
+
+	if code := publishArticle([]string{"-cover", "cover.jpg", "620"}); code != 0 {
+		t.Fatalf("the dry run exited with %d", code)
+	}
+	if len(standIn.edits) != 0 || len(standIn.uploads) != 0 {
+		t.Fatalf("the dry run published: edits %v, uploads %v", standIn.edits, standIn.uploads)
+	}
+	if _, err := os.Stat("output/620.preview"); err != nil {
+		t.Fatalf("the dry run was not recorded: %s", err)
+	}
+
+	// The article changed since the preview
+	os.WriteFile("output/620.txt", []byte("{{Infobox game}}\n{{stub}}\n"), 0666)
+	if code := publishArticle([]string{"-confirm", "-cover", "cover.jpg", "620"}); code != 1 {
+		t.Errorf("confirming a changed article exited with %d, want 1", code)
+	}
+	if len(standIn.edits) != 0 {
+		t.Fatal("an article changed since the dry run was published")
+	}
+
+	publishArticle([]string{"-cover", "cover.jpg", "620"})
+	if code := publishArticle([]string{"-confirm", "-cover", "cover.jpg", "620"}); code != 0 {
+		t.Errorf("publishing exited with %d", code)
+	}
+	if len(standIn.edits) != 1 || standIn.edits[0]["title"] != "Portal 2" || standIn.edits[0]["createonly"] != "1" {
+		t.Fatalf("edits = %v, want Portal 2 created", standIn.edits)
+	}
+	if len(standIn.uploads) != 1 || standIn.uploads[0] != "Portal 2 cover.jpg" {
+		t.Errorf("uploads = %v, want the cover", standIn.uploads)
+	}
+	if _, err := os.Stat("output/620.preview"); err == nil {
+		t.Error("the preview was kept after publishing, it could be confirmed again")
+	}
+}
+
+func TestPublishConfirmWithoutPreview(t *testing.T) {
+	standIn := newPCGWStandIn(t)
+	setupPublish(t, standIn)
+
+	if code := publishArticle([]string{"-confirm", "-cover", "cover.jpg", "620"}); code != 1 {
+		t.Errorf("confirming without a dry run exited with %d, want 1", code)
+	}
+	if len(standIn.edits) != 0 || len(standIn.uploads) != 0 {
+		t.Errorf("published without a dry run: edits %v, uploads %v", standIn.edits, standIn.uploads)
+	}
+}
+
+func TestPublishExistingPage(t *testing.T) {
+	standIn := newPCGWStandIn(t, "Portal 2")
+	setupPublish(t, standIn)
+
+	publishArticle([]string{"-cover", "cover.jpg", "620"})
+	if code := publishArticle([]string{"-confirm", "-cover", "cover.jpg", "620"}); code != 1 {
+		t.Errorf("confirming over an existing page exited with %d, want 1", code)
+	}
+	if len(standIn.edits) != 0 {
+		t.Fatalf("an existing page was edited without -overwrite: %v", standIn.edits)
+	}
+
+	publishArticle([]string{"-overwrite", "-cover", "cover.jpg", "620"})
+	publishArticle([]string{"-confirm", "-overwrite", "-cover", "cover.jpg", "620"})
+	if len(standIn.edits) != 1 || standIn.edits[0]["createonly"] != "" {
+		t.Errorf("edits = %v, want the page overwritten without createonly", standIn.edits)
+	}
+}
+
+func TestPublishArguments(t *testing.T) {
+	setupPublish(t, newPCGWStandIn(t))
+
+	for _, args := range [][]string{{}, {"620", "400"}, {"-unknown", "620"}} {
+		if code := publishArticle(args); code != 2 {
+			t.Errorf("publish %v exited with %d, want 2", args, code)
+		}
+	}
+	if code := publishArticle([]string{"400"}); code != 1 {
+		t.Errorf("publishing an app without an article exited with %d, want 1", code)
+	}
+}
+
+func TestCachedTitle(t *testing.T) {
+	setupPublish(t, newPCGWStandIn(t))
+
+	title, err := cachedTitle("620")
+	if err != nil || title.Title != "Portal 2" {
+		t.Errorf("cachedTitle(620) = %+v, %v", title, err)
+	}
+	if _, err = cachedTitle("400"); err == nil {
+		t.Error("cachedTitle(400) found a title without cached app details")
+	}
+}
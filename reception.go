@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Extracts the game slug out of both the old (/game/pc/<slug>) and the new
+// (/game/<slug>/) Metacritic links, query strings such as ?ftag= are dropped
+func ParseMetacriticLink(link string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return "", err
+	}
+
+	if !isHost(u.Host, "metacritic.com") {
+		return "", fmt.Errorf("'%s' is not a Metacritic link", link)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "game" {
+		return "", fmt.Errorf("'%s' is not a Metacritic game link", link)
+	}
+
+	slug := parts[1]
+	if slug == "pc" {
+		if len(parts) < 3 {
+			return "", fmt.Errorf("'%s' is not a Metacritic game link", link)
+		}
+		slug = parts[2]
+	}
+
+	if !regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`).MatchString(slug) {
+		return "", fmt.Errorf("invalid Metacritic slug '%s'", slug)
+	}
+	return slug, nil
+}
+
+// Extracts "<id>/<slug>" out of an OpenCritic link
+func ParseOpenCriticLink(link string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return "", err
+	}
+
+	if !isHost(u.Host, "opencritic.com") {
+		return "", fmt.Errorf("'%s' is not an OpenCritic link", link)
+	}
+
+	match := regexp.MustCompile(`^/game/(\d+)/([a-z0-9-]+)`).FindStringSubmatch(u.Path)
+	if match == nil {
+		return "", fmt.Errorf("'%s' is not an OpenCritic game link", link)
+	}
+	return match[1] + "/" + match[2], nil
+}
+
+// Whether the host is the domain or one of its subdomains
+func isHost(host, domain string) bool {
+	host = strings.ToLower(host)
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+func validateScore(score int) error {
+	if score < 0 || score > 100 {
+		return fmt.Errorf("score %d is not within 0-100", score)
+	}
+	return nil
+}
+
+func receptionRow(name, link string, score int) string {
+	return fmt.Sprintf("\n{{Infobox game/row/reception|%s|%s|%d}}", name, link, score)
+}
+
+func placeholderRow(name string) string {
+	if !AppConfig.Placeholders {
+		return ""
+	}
+	return fmt.Sprintf("\n{{Infobox game/row/reception|%s|link|rating}}", name)
+}
+
+func (game *Game) metacriticRow() string {
+	var ratings []Rating
+	if game.Data.Metacritic != nil {
+		ratings = append(ratings, *game.Data.Metacritic)
+	}
+	if rating, ok := game.Data.Ratings["Metascore"]; ok {
+		ratings = append(ratings, rating)
+	}
+
+	// Steam first, IsThereAnyDeal as a fallback
+	for _, rating := range ratings {
+		slug, err := ParseMetacriticLink(rating.URL)
+		if err == nil {
+			err = validateScore(rating.Score)
+		}
+
+		if err != nil {
+			Log.Warnf("Skipping a Metacritic rating... (%s)", err)
+			continue
+		}
+		return receptionRow("Metacritic", slug, rating.Score)
+	}
+	return placeholderRow("Metacritic")
+}
+
+func (game *Game) openCriticRow() string {
+	rating, ok := game.Data.Ratings["OpenCritic"]
+	if !ok {
+		return placeholderRow("OpenCritic")
+	}
+
+	link, err := ParseOpenCriticLink(rating.URL)
+	if err == nil {
+		err = validateScore(rating.Score)
+	}
+
+	if err != nil {
+		Log.Warnf("Skipping the OpenCritic rating... (%s)", err)
+		return placeholderRow("OpenCritic")
+	}
+	return receptionRow("OpenCritic", link, rating.Score)
+}
+
+// Every reception row, rows without data are only written as placeholders if
+// the config asks for them. The igdb infobox field is returned alongside
+func (game *Game) Reception() (rows string, igdbField string) {
+	igdbRow, igdbField := game.IGDBReception()
+	return game.metacriticRow() + game.openCriticRow() + igdbRow, igdbField
+}
@@ -0,0 +1,99 @@
+package main
+
+import "testing"
+
+func TestParseMetacriticLink(t *testing.T) {
+	tests := []struct {
+		link, want string
+	}{
+		{"https://www.metacritic.com/game/pc/portal-2?ftag=MCD-06-10aaa1f", "portal-2"},
+		{"https://www.metacritic.com/game/pc/portal-2", "portal-2"},
+		{"https://www.metacritic.com/game/portal-2/", "portal-2"},
+		{"https://www.metacritic.com/game/portal-2/critic-reviews/?platform=pc", "portal-2"},
+		{" https://metacritic.com/game/half-life-2 ", "half-life-2"},
+		{"https://www.metacritic.com/movie/portal-2", ""},
+		{"https://www.metacritic.com/game/pc", ""},
+		{"https://www.metacritic.com/game/Portal_2/", ""},
+		{"https://www.notmetacritic.com/game/portal-2/", ""},
+		{"https://www.opencritic.com/game/portal-2/", ""},
+	}
+
+	for _, test := range tests {
+		got, err := ParseMetacriticLink(test.link)
+		if len(test.want) == 0 {
+			if err == nil {
+				t.Errorf("ParseMetacriticLink(%q) = %q, want an error", test.link, got)
+			}
+		} else if err != nil || got != test.want {
+			t.Errorf("ParseMetacriticLink(%q) = %q, %v, want %q", test.link, got, err, test.want)
+		}
+	}
+}
+
+func TestParseOpenCriticLink(t *testing.T) {
+	tests := []struct {
+		link, want string
+	}{
+		{"https://opencritic.com/game/1548/portal-2", "1548/portal-2"},
+		{"https://opencritic.com/game/1548/portal-2/reviews?sort=newest", "1548/portal-2"},
+		{"https://opencritic.com/game/portal-2", ""},
+		{"https://opencritic.com/critic/1/someone", ""},
+		{"https://www.metacritic.com/game/1548/portal-2", ""},
+	}
+
+	for _, test := range tests {
+		got, err := ParseOpenCriticLink(test.link)
+		if len(test.want) == 0 {
+			if err == nil {
+				t.Errorf("ParseOpenCriticLink(%q) = %q, want an error", test.link, got)
+			}
+		} else if err != nil || got != test.want {
+			t.Errorf("ParseOpenCriticLink(%q) = %q, %v, want %q", test.link, got, err, test.want)
+		}
+	}
+}
+
+func TestValidateScore(t *testing.T) {
+	for score, valid := range map[int]bool{-1: false, 0: true, 95: true, 100: true, 101: false, 950: false} {
+		if err := validateScore(score); (err == nil) != valid {
+			t.Errorf("validateScore(%d) = %v, want valid: %t", score, err, valid)
+		}
+	}
+}
+
+func TestReception(t *testing.T) {
+	config := AppConfig
+	defer func() { AppConfig = config }()
+
+	tests := []struct {
+		name         string
+		placeholders bool
+		setup        func(game *Game)
+		want         string
+	}{
+		{"Steam Metacritic", false, func(game *Game) {
+			game.Data.Metacritic = &Rating{Score: 95, URL: "https://www.metacritic.com/game/pc/portal-2?ftag=MCD-06-10aaa1f"}
+		}, "\n{{Infobox game/row/reception|Metacritic|portal-2|95}}"},
+		{"IsThereAnyDeal fallback", false, func(game *Game) {
+			game.Data.Metacritic = &Rating{Score: 950, URL: "https://www.metacritic.com/game/pc/portal-2"}
+			game.Data.Ratings["Metascore"] = Rating{Score: 95, URL: "https://www.metacritic.com/game/portal-2/"}
+			game.Data.Ratings["OpenCritic"] = Rating{Score: 92, URL: "https://opencritic.com/game/1548/portal-2"}
+		}, "\n{{Infobox game/row/reception|Metacritic|portal-2|95}}\n{{Infobox game/row/reception|OpenCritic|1548/portal-2|92}}"},
+		{"invalid score without placeholders", false, func(game *Game) {
+			game.Data.Ratings["OpenCritic"] = Rating{Score: 101, URL: "https://opencritic.com/game/1548/portal-2"}
+		}, ""},
+		{"no ratings without placeholders", false, func(game *Game) {}, ""},
+		{"no ratings with placeholders", true, func(game *Game) {}, "\n{{Infobox game/row/reception|Metacritic|link|rating}}\n{{Infobox game/row/reception|OpenCritic|link|rating}}\n{{Infobox game/row/reception|IGDB|link|rating}}"},
+	}
+
+	for _, test := range tests {
+		AppConfig.Placeholders = test.placeholders
+
+		game := newTestGame("Portal 2")
+		game.Data.Ratings = make(map[string]Rating)
+		test.setup(&game)
+		if got, _ := game.Reception(); got != test.want {
+			t.Errorf("%s: Reception() = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Taxonomy rows written with a default value when no Steam tag matched
+var defaultTaxonomy = map[string]string{
+	"pacing":     "Real-time",
+	"controls":   "Direct control",
+	"art styles": "Realistic",
+}
+
+// API executables guessed from the system requirements
+var guessedExecutables = []string{
+	"windows 32-bit exe", "windows 64-bit exe",
+	"macos intel 32-bit app", "macos intel 64-bit app",
+	"linux 32-bit executable", "linux 64-bit executable",
+}
+
+// API executables always written as false
+var defaultedExecutables = []string{
+	"windows arm app", "mac os x powerpc app", "linux powerpc app", "linux arm app",
+	"linux 68k app", "mac os powerpc app", "mac os 68k app",
+}
+
+// Every field of the article that was guessed or left as a placeholder, along
+// with the rule that produced it
+func (game *Game) Review(gameId, article string) ReviewReport {
+	report := ReviewReport{
+		Title:   game.Title().Title,
+		Article: fmt.Sprintf("output/%s.txt", gameId),
+	}
+
+	templates := ParseTemplates(article)
+	lineOf := func(offset int) int {
+		return strings.Count(article[:offset], "\n") + 1
+	}
+
+	for i, template := range templates {
+		name := strings.ToLower(template.Name)
+
+		switch {
+		case name == "cn":
+			parent, param := enclosingParam(templates[:i], template.Start)
+			item := ReviewItem{Line: lineOf(template.Start), Rule: "citation-needed", Message: "Needs a source"}
+			if note := template.Param("1"); note != nil && len(note.Value) != 0 {
+				item.Message = note.Value
+			}
+			if parent != nil {
+				item.Template, item.Field, item.Value = parent.Name, param.Name, enumValue(param.Value)
+			}
+			report.Items = append(report.Items, item)
+			continue
+		case name == "dlc" && !containsTemplate(templates, "DLC/row", template.Start, template.End):
+			report.Items = append(report.Items, ReviewItem{Line: lineOf(template.Start), Template: template.Name, Rule: "empty-section", Message: "No DLC rows, add them or remove the section"})
+			continue
+		}
+
+		for _, param := range template.Params {
+			item := ReviewItem{Line: lineOf(param.Start), Template: template.Name, Field: param.Name, Value: param.Value}
+
+			switch {
+			case strings.EqualFold(param.Value, "unknown"):
+				item.Rule, item.Message = "unknown", "Not known from the Steam data, check the game"
+			case name == "infobox game" && param.Name == "cover":
+				item.Rule, item.Message = "cover", fmt.Sprintf("Upload File:%s (the Steam library artwork, or a better cover)", param.Value)
+			case strings.HasPrefix(name, "infobox game/row/taxonomy/") && param.Name == "1" && game.defaultedTaxonomy(name[len("infobox game/row/taxonomy/"):], param.Value):
+				item.Rule, item.Message = "default-taxonomy", "No Steam tag matched, the row was given its default value"
+			case name == "infobox game/row/reception" && param.Name == "2" && param.Value == "link":
+				item.Rule, item.Message = "placeholder", "Placeholder reception row, fill or remove it"
+			case (name == "infobox game/row/developer" || name == "infobox game/row/publisher") && param.Name == "1" && containsString(game.Data.NewCompanies, param.Value):
+				item.Rule, item.Message = "new-company", "No Company: page on PCGW, create it or use the existing page name"
+			case name == "api" && containsString(guessedExecutables, param.Name) && len(param.Value) != 0:
+				item.Rule, item.Message = "guessed-executable", "Guessed from the system requirements"
+			case name == "api" && containsString(defaultedExecutables, param.Name) && len(param.Value) != 0:
+				item.Rule, item.Message = "default-executable", "Always written as false"
+			case (name == "game data/config" || name == "game data/saves") && param.Name == "2" && len(param.Value) == 0:
+				item.Rule, item.Message = "empty-path", fmt.Sprintf("No %s file location", strings.TrimPrefix(name, "game data/"))
+			default:
+				continue
+			}
+			report.Items = append(report.Items, item)
+		}
+	}
+
+	for _, conflict := range game.Data.DRMConflicts {
+		report.Items = append(report.Items, ReviewItem{Template: "Availability/row", Rule: "drm-conflict", Message: conflict})
+	}
+
+	for _, store := range game.Data.UnknownStores {
+		report.Items = append(report.Items, ReviewItem{Template: "Availability/row", Value: store, Rule: "unknown-store", Message: "Store missing from the store registry, not added to Availability"})
+	}
+
+	for _, issue := range Lint(article, Schema) {
+		report.Items = append(report.Items, ReviewItem{Line: issue.Line, Template: issue.Template, Rule: "lint", Message: issue.Message})
+	}
+
+	sort.SliceStable(report.Items, func(i, j int) bool {
+		return report.Items[i].Line < report.Items[j].Line
+	})
+	return report
+}
+
+func (game *Game) defaultedTaxonomy(row, value string) bool {
+	if defaultValue, ok := defaultTaxonomy[row]; !ok || defaultValue != value {
+		return false
+	}
+
+	for _, rows := range game.Data.TagRows {
+		if containsString(rows, row) {
+			return false
+		}
+	}
+	return true
+}
+
+// The innermost template parameter containing the offset
+func enclosingParam(templates []WikiTemplate, offset int) (*WikiTemplate, *WikiParam) {
+	for i := len(templates) - 1; i >= 0; i-- {
+		if templates[i].Start >= offset || templates[i].End <= offset {
+			continue
+		}
+
+		for j := range templates[i].Params {
+			if param := &templates[i].Params[j]; param.Start <= offset && offset < param.ValueEnd {
+				return &templates[i], param
+			}
+		}
+	}
+	return nil, nil
+}
+
+func containsTemplate(templates []WikiTemplate, name string, start, end int) bool {
+	for _, template := range templates {
+		if template.Start > start && template.End <= end && templateNameIs(template.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (report ReviewReport) String() string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Review checklist for %s (%s), %d item(s)\n\n", report.Title, report.Article, len(report.Items)))
+
+	for _, item := range report.Items {
+		output.WriteString("- [ ] ")
+		if item.Line != 0 {
+			output.WriteString(fmt.Sprintf("line %d: ", item.Line))
+		}
+		if len(item.Template) != 0 {
+			output.WriteString("{{" + item.Template + "}} ")
+		}
+		if len(item.Field) != 0 {
+			output.WriteString(fmt.Sprintf("%s = %s ", item.Field, item.Value))
+		} else if len(item.Value) != 0 {
+			output.WriteString(item.Value + " ")
+		}
+		output.WriteString(fmt.Sprintf("- %s [%s]\n", item.Message, item.Rule))
+	}
+	return output.String()
+}
+
+// Writes the report to output/<appid>.review.json and output/<appid>.review.txt
+func (report ReviewReport) Write(gameId string) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(fmt.Sprintf("output/%s.review.json", gameId), data, 0777); err != nil {
+		return err
+	}
+	return os.WriteFile(fmt.Sprintf("output/%s.review.txt", gameId), []byte(report.String()), 0777)
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Template parameters the generator fills, and where their values come from.
+// The first matching entry wins. Only values read straight off the Steam
+// store are cited, the rest is tracked for the review
+var provenanceFields = []Provenance{
+	{Template: "Infobox game/row/developer", Param: "1", Source: SOURCE_STEAM_API},
+	{Template: "Infobox game/row/publisher", Param: "1", Source: SOURCE_STEAM_API},
+	{Template: "Infobox game/row/date", Param: "2", Source: SOURCE_STEAM_API},
+	{Template: "Infobox game/row/reception", Param: "*", Key: "IGDB", Source: SOURCE_IGDB},
+	{Template: "Infobox game/row/reception", Param: "*", Source: SOURCE_ITAD},
+	{Template: "Infobox game/row/taxonomy/monetization", Param: "1", Source: SOURCE_STEAM_API},
+	{Template: "Infobox game/row/taxonomy/microtransactions", Param: "1", Source: SOURCE_STEAM_STORE},
+	{Template: "Infobox game/row/taxonomy/modes", Param: "1", Source: SOURCE_STEAM_API},
+	{Template: "Infobox game/row/taxonomy/pacing", Param: "1", Source: SOURCE_STEAM_STORE},
+	{Template: "Infobox game/row/taxonomy/perspectives", Param: "1", Source: SOURCE_STEAM_STORE},
+	{Template: "Infobox game/row/taxonomy/controls", Param: "1", Source: SOURCE_STEAM_STORE},
+	{Template: "Infobox game/row/taxonomy/genres", Param: "1", Source: SOURCE_STEAM_STORE},
+	{Template: "Infobox game/row/taxonomy/sports", Param: "1", Source: SOURCE_STEAM_STORE},
+	{Template: "Infobox game/row/taxonomy/vehicles", Param: "1", Source: SOURCE_STEAM_STORE},
+	{Template: "Infobox game/row/taxonomy/art styles", Param: "1", Source: SOURCE_STEAM_STORE},
+	{Template: "Infobox game/row/taxonomy/themes", Param: "1", Source: SOURCE_STEAM_STORE},
+	{Template: "Infobox game/row/taxonomy/series", Param: "1", Source: SOURCE_STEAM_STORE},
+	{Template: "Infobox game", Param: "steam appid side", Source: SOURCE_STEAM_API},
+	{Template: "Infobox game", Param: "official site", Source: SOURCE_STEAM_API},
+	{Template: "Infobox game", Param: "gogcom id", Source: SOURCE_GOG},
+	{Template: "Infobox game", Param: "gogcom id side", Source: SOURCE_GOG},
+	{Template: "Infobox game", Param: "igdb", Source: SOURCE_IGDB},
+	{Template: "Infobox game", Param: "hltb", Source: SOURCE_WIKIDATA},
+	{Template: "Infobox game", Param: "lutris", Source: SOURCE_WIKIDATA},
+	{Template: "Infobox game", Param: "mobygames", Source: SOURCE_WIKIDATA},
+	{Template: "Infobox game", Param: "strategywiki", Source: SOURCE_WIKIDATA},
+	{Template: "Infobox game", Param: "wikipedia", Source: SOURCE_WIKIDATA},
+	{Template: "Infobox game", Param: "winehq", Source: SOURCE_WIKIDATA},
+	{Template: "Availability/row", Param: "*", Key: "Steam", Source: SOURCE_STEAM_API},
+	{Template: "Availability/row", Param: "*", Source: SOURCE_ITAD},
+	{Template: "Monetization", Param: "*", Source: SOURCE_STEAM_API, Cite: true},
+	{Template: "Microtransactions", Param: "*", Source: SOURCE_STEAM_STORE},
+	{Template: "Save game cloud syncing", Param: "steam cloud", Source: SOURCE_STEAM_API, Cite: true},
+	{Template: "Input", Param: "controller support", Source: SOURCE_STEAM_API, Cite: true},
+	{Template: "Input", Param: "full controller", Source: SOURCE_STEAM_API, Cite: true},
+	{Template: "Audio", Param: "subtitles", Source: SOURCE_STEAM_API, Cite: true},
+	{Template: "L10n/switch", Param: "interface", Source: SOURCE_STEAM_API, Cite: true, Notes: "ref"},
+	{Template: "L10n/switch", Param: "audio", Source: SOURCE_STEAM_API},
+	{Template: "L10n/switch", Param: "subtitles", Source: SOURCE_STEAM_API},
+	{Template: "Network/Multiplayer", Param: "local play", Source: SOURCE_STEAM_API, Cite: true},
+	{Template: "Network/Multiplayer", Param: "lan play", Source: SOURCE_STEAM_API, Cite: true},
+	{Template: "Network/Multiplayer", Param: "online play", Source: SOURCE_STEAM_API, Cite: true},
+	{Template: "API", Param: "direct3d versions", Source: SOURCE_STEAM_API, Cite: true, Notes: "direct3d notes"},
+	{Template: "API", Param: "windows 32-bit exe", Source: SOURCE_STEAM_API},
+	{Template: "API", Param: "windows 64-bit exe", Source: SOURCE_STEAM_API},
+	{Template: "API", Param: "macos intel 32-bit app", Source: SOURCE_STEAM_API},
+	{Template: "API", Param: "macos intel 64-bit app", Source: SOURCE_STEAM_API},
+	{Template: "API", Param: "linux 32-bit executable", Source: SOURCE_STEAM_API},
+	{Template: "API", Param: "linux 64-bit executable", Source: SOURCE_STEAM_API},
+	{Template: "System requirements", Param: "*", Source: SOURCE_STEAM_API},
+}
+
+// Records a source the game data was read from, sources without a fetch
+// time (such as a missing cache file) are left out
+func (game *Game) addSource(key, name, url string, retrieved time.Time) {
+	if retrieved.IsZero() {
+		return
+	}
+
+	if game.Data.Sources == nil {
+		game.Data.Sources = make(map[string]Source)
+	}
+	game.Data.Sources[key] = Source{Name: name, URL: url, Retrieved: retrieved}
+}
+
+// The modification time of a cache file, zero if it does not exist
+func cacheTime(fileName string) time.Time {
+	info, err := os.Stat(fileName)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// The provenance table, with the Availability rows of subscription services
+// attributed to the subscription catalogue
+func (game *Game) provenance() (fields []Provenance) {
+	for _, subscription := range game.Data.Subscriptions {
+		fields = append(fields, Provenance{Template: "Availability/row", Param: "*", Key: subscription.Service.Store, Source: SOURCE_SUBSCRIPTIONS})
+	}
+	return append(fields, provenanceFields...)
+}
+
+func (game *Game) sourceOf(template *WikiTemplate, param string) *Provenance {
+	fields := game.provenance()
+	for i := range fields {
+		field := &fields[i]
+		if !templateNameIs(template.Name, field.Template) || (field.Param != "*" && field.Param != param) {
+			continue
+		}
+
+		if len(field.Key) != 0 {
+			key := template.Param("1")
+			if key == nil || !strings.EqualFold(key.Value, field.Key) {
+				continue
+			}
+		}
+		return field
+	}
+	return nil
+}
+
+// Every value of the article filled from a known source. Empty and unknown
+// values were not filled, they are left out
+func (game *Game) SourcedValues(article string) (values []SourcedValue) {
+	templates := ParseTemplates(article)
+	for i := range templates {
+		template := &templates[i]
+		for _, param := range template.Params {
+			if len(param.Value) == 0 || strings.EqualFold(param.Value, "unknown") {
+				continue
+			}
+
+			field := game.sourceOf(template, param.Name)
+			if field == nil {
+				continue
+			}
+
+			source, ok := game.Data.Sources[field.Source]
+			if !ok {
+				continue
+			}
+
+			values = append(values, SourcedValue{
+				Line:      strings.Count(article[:param.ValueStart], "\n") + 1,
+				Template:  template.Name,
+				Field:     param.Name,
+				Value:     param.Value,
+				Source:    field.Source,
+				Retrieved: source.Retrieved,
+			})
+		}
+	}
+
+	// Templates are found by their opening braces, the values of the rows
+	// nested in the infobox come before its own
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].Line < values[j].Line
+	})
+	return
+}
+
+// Adds a reference to every cited value. A source is cited in full the first
+// time, later citations reuse its named reference. Values which are false
+// only mean Steam does not list the feature, those are not cited, nor are
+// sources without a link to check them against
+func (game *Game) AddReferences(article string) (string, error) {
+	var edits []WikiEdit
+	cited := make(map[string]bool)
+
+	templates := ParseTemplates(article)
+	for i := range templates {
+		template := &templates[i]
+		for p := range template.Params {
+			param := &template.Params[p]
+			if len(param.Value) == 0 || strings.EqualFold(param.Value, "unknown") || strings.EqualFold(param.Value, "false") {
+				continue
+			}
+
+			field := game.sourceOf(template, param.Name)
+			if field == nil || !field.Cite {
+				continue
+			}
+
+			source, ok := game.Data.Sources[field.Source]
+			if !ok || len(source.URL) == 0 {
+				continue
+			}
+
+			ref := fmt.Sprintf(`<ref name="%s"/>`, field.Source)
+			if !cited[field.Source] {
+				ref = fmt.Sprintf(`<ref name="%s">{{Refurl|url=%s|title=%s|date=%s}}</ref>`, field.Source, source.URL, source.Name, source.Retrieved.Format("2006-01-02"))
+				cited[field.Source] = true
+			}
+
+			notes := template.Param(field.Notes)
+			if len(field.Notes) == 0 {
+				notes = template.Param(param.Name + " notes")
+			}
+			if notes == nil {
+				notes = param
+			}
+			edits = append(edits, notes.Append(article, ref))
+		}
+	}
+	return ApplyEdits(article, edits)
+}
+
+// Writes the sources and the values filled from them to output/<appid>.sources.json
+func (game *Game) WriteSources(gameId, article string) error {
+	data, err := json.MarshalIndent(struct {
+		Sources map[string]Source `json:"sources"`
+		Values  []SourcedValue    `json:"values"`
+	}{game.Data.Sources, game.SourcedValues(article)}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fmt.Sprintf("output/%s.sources.json", gameId), data, 0777)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const citedArticle = `{{Input
+|controller support       = true
+|controller support notes =
+|full controller          = false
+|full controller notes    =
+}}
+{{Audio
+|subtitles                = unknown
+|subtitles notes          =
+}}
+{{Network/Multiplayer
+|local play               = true
+|local play notes         =
+|lan play                 = true
+|lan play notes           = <!-- Checked by hand -->
+}}`
+
+func newCitedGame(url string) Game {
+	game := newTestGame("Portal 2")
+	game.addSource(SOURCE_STEAM_API, "Steam API app details for Portal 2", url, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	return game
+}
+
+func TestAddReferences(t *testing.T) {
+	game := newCitedGame(API_LINK + "620")
+	article, err := game.AddReferences(citedArticle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	full := `<ref name="steam-appdetails">{{Refurl|url=https://store.steampowered.com/api/appdetails?appids=620|title=Steam API app details for Portal 2|date=2024-05-01}}</ref>`
+	named := `<ref name="steam-appdetails"/>`
+	for _, want := range []string{
+		"|controller support notes =" + full + "\n",
+		"|local play notes         =" + named + "\n",
+		"|lan play notes           = " + named + "<!-- Checked by hand -->\n",
+	} {
+		if !strings.Contains(article, want) {
+			t.Errorf("the article does not contain %q:\n%s", want, article)
+		}
+	}
+
+	if count := strings.Count(article, "{{Refurl"); count != 1 {
+		t.Errorf("the source is cited in full %d time(s), want once", count)
+	}
+
+	// Nothing is cited for values Steam does not list
+	for _, want := range []string{"|full controller notes    =\n", "|subtitles notes          =\n"} {
+		if !strings.Contains(article, want) {
+			t.Errorf("a false or unknown value was cited, want %q:\n%s", want, article)
+		}
+	}
+}
+
+func TestAddReferencesWithoutURL(t *testing.T) {
+	game := newCitedGame("")
+	article, err := game.AddReferences(citedArticle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if article != citedArticle {
+		t.Errorf("a source without a link was cited:\n%s", article)
+	}
+}
+
+func TestWikidataURL(t *testing.T) {
+	game := newTestGame("Portal 2")
+	if url := game.wikidataURL("620"); url != "https://www.wikidata.org/w/index.php?search=haswbstatement%3AP1733%3D620" {
+		t.Errorf("wikidataURL without an item = %q", url)
+	}
+
+	game.Data.ExternalIDs = map[string]string{"item": "Q279744"}
+	if url := game.wikidataURL("620"); url != "https://www.wikidata.org/wiki/Q279744" {
+		t.Errorf("wikidataURL = %q", url)
+	}
+}
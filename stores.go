@@ -0,0 +1,75 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+//go:embed stores.json
+var defaultStores []byte
+
+var StoreRegistry []ValidStore
+
+// Loads the store registry from the given file, or from the embedded one if
+// the file does not exist
+func LoadStores(fileName string) (stores []ValidStore, err error) {
+	data := defaultStores
+	if len(fileName) != 0 {
+		if override, readErr := os.ReadFile(fileName); readErr == nil {
+			data = override
+		} else if !os.IsNotExist(readErr) {
+			return nil, readErr
+		}
+	}
+
+	if err = json.Unmarshal(data, &stores); err != nil {
+		return nil, fmt.Errorf("failed to parse the store registry (%s)", err)
+	}
+
+	for i := range stores {
+		for _, pattern := range stores[i].IDPatterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ID pattern for '%s' (%s)", stores[i].DisplayName, err)
+			}
+			stores[i].patterns = append(stores[i].patterns, re)
+		}
+	}
+	return
+}
+
+func FindStore(name string) (ValidStore, bool) {
+	for _, store := range StoreRegistry {
+		for _, scrapeName := range store.ScrapeNames {
+			if strings.EqualFold(scrapeName, name) {
+				return store, true
+			}
+		}
+	}
+	return ValidStore{}, false
+}
+
+// The store of an Availability row, by the name the row uses
+func FindStoreByDisplayName(name string) (ValidStore, bool) {
+	for _, store := range StoreRegistry {
+		if strings.EqualFold(store.DisplayName, name) {
+			return store, true
+		}
+	}
+	return ValidStore{}, false
+}
+
+// Extracts the ID used by the Availability row from a store link, the whole
+// link is kept if none of the patterns match
+func (store *ValidStore) ExtractID(link string) string {
+	for _, re := range store.patterns {
+		if match := re.FindStringSubmatch(link); len(match) > 1 {
+			return match[1]
+		}
+	}
+	return link
+}
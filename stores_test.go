@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestExtractID(t *testing.T) {
+	tests := []struct {
+		store, link, want string
+	}{
+		{"Amazon", "https://www.amazon.com/Portal-2-PC/dp/B004XMZZKQ?ref=x", "B004XMZZKQ"},
+		{"Battle.net", "https://shop.battle.net/en-us/product/diablo-iv", "diablo-iv"},
+		{"Discord", "https://discord.com/store/skus/471376328319303681/portal", "471376328319303681"},
+		{"EA app", "https://www.ea.com/games/mass-effect/mass-effect-legendary-edition/buy/pc", "mass-effect/mass-effect-legendary-edition"},
+		{"EA app", "https://www.origin.com/gbr/en-us/store/titanfall/titanfall-2/", "titanfall/titanfall-2"},
+		{"Epic Games Store", "https://store.epicgames.com/en-US/p/portal-2", "portal-2"},
+		{"Fanatical", "https://www.fanatical.com/en/game/portal-2", "portal-2"},
+		{"GamersGate", "https://www.gamersgate.com/product/portal-2/", "portal-2"},
+		{"GamesPlanet", "https://us.gamesplanet.com/game/portal-2-steam-key--1234-1", "portal-2-steam-key--1234-1"},
+		{"GOG.com", "https://www.gog.com/en/game/portal_2?pp=1", "portal_2"},
+		{"Green Man Gaming", "https://www.greenmangaming.com/games/portal-2/", "portal-2"},
+		{"Humble", "https://www.humblebundle.com/store/portal-2?partner=x", "portal-2"},
+		{"itch.io", "https://dev.itch.io/portal-2/purchase", "https://dev.itch.io/portal-2"},
+		{"Mac App Store", "https://apps.apple.com/us/app/portal-2/id1234567890", "id1234567890"},
+		{"Microsoft Store", "https://www.microsoft.com/en-us/p/portal-2/9NBLGGH4R2R6?activetab=pivot", "9NBLGGH4R2R6"},
+		{"Ubisoft Store", "https://store.ubisoft.com/us/game/far-cry-6/5e849c6b5cdf9a21c0b4e731.html", "far-cry-6/5e849c6b5cdf9a21c0b4e731"},
+		{"WinGameStore", "https://www.wingamestore.com/product/1234/Portal-2/", "1234/Portal-2"},
+		{"Zoom Platform", "https://www.zoom-platform.com/product/portal-2", "portal-2"},
+		// The whole link is kept if it is not a product page
+		{"GOG.com", "https://www.gog.com/en/games", "https://www.gog.com/en/games"},
+	}
+
+	for _, test := range tests {
+		store, ok := FindStoreByDisplayName(test.store)
+		if !ok {
+			t.Errorf("%s is not in the registry", test.store)
+			continue
+		}
+		if got := store.ExtractID(test.link); got != test.want {
+			t.Errorf("%s: ExtractID(%q) = %q, want %q", test.store, test.link, got, test.want)
+		}
+	}
+}
+
+func TestStoreRegistryDRM(t *testing.T) {
+	for _, store := range StoreRegistry {
+		if len(store.DRM) == 0 {
+			t.Errorf("%s has no default DRM", store.DisplayName)
+		}
+	}
+}
+
+func TestLoadStoresOverride(t *testing.T) {
+	chdirTemp(t)
+
+	// A missing file falls back to the embedded registry
+	stores, err := LoadStores("stores.json")
+	if err != nil || len(stores) != len(StoreRegistry) {
+		t.Fatalf("LoadStores without a file = %d stores, %v", len(stores), err)
+	}
+
+	override := `[{"scrape_names": ["IndieGala Store"], "display_name": "IndieGala", "id_patterns": ["indiegala\\.com/store/game/([^/?#]+)"], "drm": "DRM-free", "platforms": "Windows"}]`
+	os.WriteFile("stores.json", []byte(override), 0666)
+	if stores, err = LoadStores("stores.json"); err != nil {
+		t.Fatal(err)
+	}
+	if len(stores) != 1 || stores[0].DisplayName != "IndieGala" {
+		t.Fatalf("LoadStores = %+v, want the override", stores)
+	}
+	if id := stores[0].ExtractID("https://www.indiegala.com/store/game/portal-2/12345"); id != "portal-2" {
+		t.Errorf("ExtractID with the override = %q", id)
+	}
+
+	for _, invalid := range []string{`[{"display_name": "Broken", "id_patterns": ["(unclosed"]}]`, `{"display_name": "Broken"}`} {
+		os.WriteFile("stores.json", []byte(invalid), 0666)
+		if _, err = LoadStores("stores.json"); err == nil {
+			t.Errorf("LoadStores(%s) did not fail", invalid)
+		}
+	}
+}
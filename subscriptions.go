@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Loads the subscription service catalogue, either from a local JSON snapshot
+// or from an endpoint serving the same format
+func LoadSubscriptionCatalogue(source string) (catalogue SubscriptionCatalogue, err error) {
+	var data []byte
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		response, requestErr := makeRequest(source)
+		if err = checkRequest(response, requestErr); err != nil {
+			return
+		}
+		defer response.Body.Close()
+
+		data, err = parseResponseToBody(response)
+	} else {
+		data, err = os.ReadFile(source)
+	}
+
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(data, &catalogue)
+	return
+}
+
+func (game *Game) findSubscriptions(catalogue SubscriptionCatalogue, appId string) {
+	id, err := strconv.ParseInt(appId, 10, 64)
+	if err != nil {
+		return
+	}
+
+	game.Data.SubscriptionSnapshot = catalogue.Date
+	for _, service := range catalogue.Services {
+		for _, app := range service.Apps {
+			if app.SteamAppID != id {
+				continue
+			}
+
+			game.Data.Subscriptions = append(game.Data.Subscriptions, Subscription{
+				Service: service,
+				ID:      app.ID,
+			})
+			break
+		}
+	}
+}
+
+func (game *Game) SubscriptionServices() (names []string) {
+	for _, subscription := range game.Data.Subscriptions {
+		names = appendUnique(names, subscription.Service.Name)
+	}
+	return
+}
+
+// Notes for an Availability row of the given store, if the game is part of a
+// subscription service sold through it
+func (game *Game) SubscriptionNote(store string) string {
+	var notes []string
+	for _, subscription := range game.Data.Subscriptions {
+		if subscription.Service.Store == store {
+			notes = append(notes, fmt.Sprintf("Included with {{Store feature|%s}}.", subscription.Service.Name))
+		}
+	}
+	return strings.Join(notes, " ")
+}
+
+// Availability rows of subscription services whose store has no row yet
+func (game *Game) SubscriptionRows() (output string) {
+	var written []string
+	for _, subscription := range game.Data.Subscriptions {
+		store := subscription.Service.Store
+		if _, ok := game.Data.Stores[store]; ok || containsString(written, store) {
+			continue
+		}
+		written = append(written, store)
+
+		output += fmt.Sprintf("\n{{Availability/row| %s | %s | %s | %s | | %s }}", store, subscription.ID, subscription.Service.DRM, game.SubscriptionNote(store), subscription.Service.Platforms)
+	}
+
+	if len(game.Data.Subscriptions) != 0 && len(game.Data.SubscriptionSnapshot) != 0 {
+		output += "\n" + formatComment("Subscription services from the "+game.Data.SubscriptionSnapshot+" catalogue snapshot")
+	}
+	return
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+const subscriptionCatalogue = `{
+	"date": "2024-05-01",
+	"services": [
+		{"name": "PC Game Pass", "store": "Microsoft Store", "drm": "Microsoft Store", "platforms": "Windows", "apps": [{"steam_appid": 620, "id": "9NBLGGH4R2R6"}]},
+		{"name": "EA Play", "store": "EA app", "drm": "EA app", "platforms": "Windows", "apps": [{"steam_appid": 400, "id": "portal"}, {"steam_appid": 620, "id": "portal-2"}]},
+		{"name": "Ubisoft+", "store": "Ubisoft Store", "drm": "Ubisoft Connect", "platforms": "Windows", "apps": [{"steam_appid": 400, "id": "portal"}]}
+	]
+}`
+
+func TestLoadSubscriptionCatalogue(t *testing.T) {
+	chdirTemp(t)
+	os.WriteFile("subscriptions.json", []byte(subscriptionCatalogue), 0666)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/subscriptions.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(subscriptionCatalogue))
+	}))
+	defer server.Close()
+
+	for _, source := range []string{"subscriptions.json", server.URL + "/subscriptions.json"} {
+		catalogue, err := LoadSubscriptionCatalogue(source)
+		if err != nil {
+			t.Errorf("LoadSubscriptionCatalogue(%s): %s", source, err)
+			continue
+		}
+		if catalogue.Date != "2024-05-01" || len(catalogue.Services) != 3 {
+			t.Errorf("LoadSubscriptionCatalogue(%s) = %+v", source, catalogue)
+		}
+	}
+
+	if _, err := LoadSubscriptionCatalogue("missing.json"); !os.IsNotExist(err) {
+		t.Errorf("a missing snapshot returned %v, want a not-exist error", err)
+	}
+	if _, err := LoadSubscriptionCatalogue(server.URL + "/missing.json"); err == nil {
+		t.Error("an endpoint returning 404 did not fail")
+	}
+}
+
+func newSubscribedGame(t *testing.T) Game {
+	chdirTemp(t)
+	os.WriteFile("subscriptions.json", []byte(subscriptionCatalogue), 0666)
+	catalogue, err := LoadSubscriptionCatalogue("subscriptions.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game := newTestGame("Portal 2")
+	game.findSubscriptions(catalogue, "620")
+	return game
+}
+
+func TestFindSubscriptions(t *testing.T) {
+	game := newSubscribedGame(t)
+
+	if got := strings.Join(game.SubscriptionServices(), ", "); got != "PC Game Pass, EA Play" {
+		t.Errorf("services = %q, want PC Game Pass and EA Play", got)
+	}
+	if game.Data.Subscriptions[1].ID != "portal-2" {
+		t.Errorf("EA Play ID = %q, want the one of app 620", game.Data.Subscriptions[1].ID)
+	}
+	if game.Data.SubscriptionSnapshot != "2024-05-01" {
+		t.Errorf("snapshot = %q", game.Data.SubscriptionSnapshot)
+	}
+
+	game = newTestGame("Portal 2")
+	game.findSubscriptions(SubscriptionCatalogue{}, "not an ID")
+	if len(game.Data.Subscriptions) != 0 {
+		t.Errorf("subscriptions were found for an invalid app ID: %+v", game.Data.Subscriptions)
+	}
+}
+
+func TestSubscriptionRows(t *testing.T) {
+	game := newSubscribedGame(t)
+	game.Data.Stores = map[string]Store{"Microsoft Store": {URL: "9NBLGGH4R2R6"}}
+
+	if note := game.SubscriptionNote("Microsoft Store"); note != "Included with {{Store feature|PC Game Pass}}." {
+		t.Errorf("Microsoft Store note = %q", note)
+	}
+
+	rows := game.SubscriptionRows()
+	if strings.Contains(rows, "| Microsoft Store |") {
+		t.Errorf("a second Microsoft Store row was written:%s", rows)
+	}
+	if want := "\n{{Availability/row| EA app | portal-2 | EA app | Included with {{Store feature|EA Play}}. | | Windows }}"; !strings.HasPrefix(rows, want) {
+		t.Errorf("rows = %q, want the EA app row first", rows)
+	}
+	if !strings.Contains(rows, "2024-05-01 catalogue snapshot") {
+		t.Errorf("the snapshot date was not noted:%s", rows)
+	}
+}
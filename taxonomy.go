@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/net/html"
+)
+
+const IGNORED_TAG = "(ignored)"
+
+func ScrapeAppTags(page string) []string {
+	dirtyTags := regexp.MustCompile(`<a href=".+" class="app_tag" style=".+">\s+(.+)\s+<\/a>{1,}`).FindAllStringSubmatch(page, 50)
+	var appTags []string
+	for _, tag := range dirtyTags {
+		cleanTag := html.UnescapeString(tag[1])
+		cleanTag = strings.Replace(cleanTag, "+", "", 1)
+		cleanTag = strings.Replace(cleanTag, "Point & Click", "Point and Select", 1)
+		cleanTag = strings.TrimSpace(cleanTag)
+
+		appTags = append(appTags, cleanTag)
+	}
+	return appTags
+}
+
+func (game *Game) SetTaxonomy(tags []string) {
+	game.Data.AppTags = tags
+
+	game.SetPacing(tags)
+	game.SetPerspective(tags)
+	game.SetControls(tags)
+	game.SetGenres(tags)
+	game.SetSports(tags)
+	game.SetVehicles(tags)
+	game.SetArtStyles(tags)
+	game.SetThemes(tags)
+}
+
+// Returns the values that matched at least one tag and records which
+// taxonomy row every matching tag fed, so it can be reported later on
+func (game *Game) matchTags(row string, tags []string, values []string) (matched []string) {
+	if game.Data.TagRows == nil {
+		game.Data.TagRows = make(map[string][]string)
+	}
+
+	for _, value := range values {
+		found := false
+		for _, tag := range tags {
+			if !strings.Contains(strings.ToLower(tag), strings.ToLower(value)) {
+				continue
+			}
+
+			found = true
+			if !containsString(game.Data.TagRows[tag], row) {
+				game.Data.TagRows[tag] = append(game.Data.TagRows[tag], row)
+			}
+		}
+
+		if found {
+			matched = append(matched, value)
+		}
+	}
+	return
+}
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func NewTaxonomyReport() *TaxonomyReport {
+	return &TaxonomyReport{Tags: make(map[string]*TagUsage)}
+}
+
+func (report *TaxonomyReport) Add(game *Game) {
+	report.Apps++
+
+	for _, tag := range game.Data.AppTags {
+		usage, ok := report.Tags[tag]
+		if !ok {
+			usage = &TagUsage{Rows: make(map[string]int)}
+			report.Tags[tag] = usage
+		}
+
+		usage.Count++
+		rows := game.Data.TagRows[tag]
+		if len(rows) == 0 {
+			usage.Rows[IGNORED_TAG]++
+			continue
+		}
+
+		for _, row := range rows {
+			usage.Rows[row]++
+		}
+	}
+}
+
+func (report *TaxonomyReport) String() string {
+	tags := make([]string, 0, len(report.Tags))
+	for tag := range report.Tags {
+		tags = append(tags, tag)
+	}
+
+	// Most frequent tags first, ignored tags are what curators are after
+	sort.Slice(tags, func(i, j int) bool {
+		a, b := report.Tags[tags[i]], report.Tags[tags[j]]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return tags[i] < tags[j]
+	})
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Apps scanned: %d\n\n", report.Apps))
+
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Tag\tCount\tTaxonomy rows")
+	for _, tag := range tags {
+		usage := report.Tags[tag]
+
+		rows := make([]string, 0, len(usage.Rows))
+		for row, count := range usage.Rows {
+			rows = append(rows, fmt.Sprintf("%s (%d)", row, count))
+		}
+		sort.Strings(rows)
+
+		fmt.Fprintf(w, "%s\t%d\t%s\n", tag, usage.Count, strings.Join(rows, ", "))
+	}
+	w.Flush()
+
+	return sb.String()
+}
+
+// Builds the taxonomy report over every scraped Steam page in the cache
+func RunTaxonomyReport() {
+	pages, err := filepath.Glob("cache/*.html")
+	if err != nil || len(pages) == 0 {
+		Log.Errorf("No scraped Steam pages were found in the cache directory...")
+		return
+	}
+
+	report := NewTaxonomyReport()
+	for _, page := range pages {
+		data, err := os.ReadFile(page)
+		if err != nil {
+			Log.Warnf("Failed to read '%s'... (%s)", page, err)
+			continue
+		}
+
+		var game Game
+		game.SetTaxonomy(ScrapeAppTags(string(data)))
+		report.Add(&game)
+	}
+
+	output := report.String()
+	fmt.Print(output)
+
+	os.Mkdir("output", 0777)
+	if err = os.WriteFile("output/taxonomy-report.txt", []byte(output), 0777); err != nil {
+		Log.Warnf("Failed to write the taxonomy report...")
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMatchTags(t *testing.T) {
+	var game Game
+	tags := []string{"Puzzle Platformer", "Puzzle", "Action", "Space Sim"}
+
+	matched := game.matchTags("genres", tags, []string{"Puzzle", "Platform", "Shooter"})
+	if want := []string{"Puzzle", "Platform"}; !reflect.DeepEqual(matched, want) {
+		t.Errorf("matched = %q, want %q", matched, want)
+	}
+
+	// A tag feeding several rows is recorded under each of them, once
+	game.matchTags("genres", tags, []string{"Puzzle"})
+	game.matchTags("vehicles", tags, []string{"Space"})
+	want := map[string][]string{
+		"Puzzle Platformer": {"genres"},
+		"Puzzle":            {"genres"},
+		"Space Sim":         {"vehicles"},
+	}
+	if !reflect.DeepEqual(game.Data.TagRows, want) {
+		t.Errorf("TagRows = %v, want %v", game.Data.TagRows, want)
+	}
+}
+
+func TestSetTaxonomy(t *testing.T) {
+	var game Game
+	game.SetTaxonomy([]string{"Puzzle", "First-Person", "Golf", "Great Soundtrack"})
+
+	if game.Data.Sports != "Golf" || game.Data.Vehicles != "" || game.Data.Pacing != "Real-time" {
+		t.Errorf("sports %q, vehicles %q, pacing %q", game.Data.Sports, game.Data.Vehicles, game.Data.Pacing)
+	}
+	if _, ok := game.Data.TagRows["Great Soundtrack"]; ok {
+		t.Error("a tag matching no row was recorded")
+	}
+}
+
+func TestTaxonomyReport(t *testing.T) {
+	report := NewTaxonomyReport()
+	for _, tags := range [][]string{{"Puzzle", "Great Soundtrack"}, {"Puzzle", "Golf"}, {"Great Soundtrack"}} {
+		var game Game
+		game.SetTaxonomy(tags)
+		report.Add(&game)
+	}
+
+	if report.Apps != 3 {
+		t.Errorf("Apps = %d, want 3", report.Apps)
+	}
+	if usage := report.Tags["Great Soundtrack"]; usage.Count != 2 || usage.Rows[IGNORED_TAG] != 2 {
+		t.Errorf("Great Soundtrack = %+v, want 2 uses, both ignored", usage)
+	}
+	if usage := report.Tags["Puzzle"]; usage.Count != 2 || usage.Rows["genres"] != 2 || usage.Rows[IGNORED_TAG] != 0 {
+		t.Errorf("Puzzle = %+v, want 2 uses in the genres", usage)
+	}
+	if usage := report.Tags["Golf"]; usage.Count != 1 || usage.Rows["sports"] != 1 {
+		t.Errorf("Golf = %+v, want 1 use in the sports", usage)
+	}
+
+	// The most frequent tags come first, ties by name
+	lines := strings.Split(report.String(), "\n")
+	if len(lines) < 6 || !strings.HasPrefix(lines[3], "Great Soundtrack") || !strings.HasPrefix(lines[4], "Puzzle") || !strings.HasPrefix(lines[5], "Golf") {
+		t.Errorf("report:\n%s", report)
+	}
+}
+
+func TestRunTaxonomyReport(t *testing.T) {
+	chdirTemp(t)
+	os.Mkdir("cache", 0777)
+
+	tag := func(name string) string {
+		return "<a href=\"https://store.steampowered.com/tags/en/x/\" class=\"app_tag\" style=\"display: none;\">\n\t\t\t\t\t\t\t\t\t\t\t\t" + name + "\t\t\t\t\t\t\t\t\t\t\t\t</a>\n"
+	}
+	pages := map[string]string{
+		"cache/620.html": tag("Puzzle") + tag("Co-op") + tag("First-Person"),
+		"cache/400.html": tag("Puzzle") + tag("Great Soundtrack"),
+		"cache/620.json": "{}",
+	}
+	for name, page := range pages {
+		if err := os.WriteFile(name, []byte(page), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	RunTaxonomyReport()
+	data, err := os.ReadFile("output/taxonomy-report.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := string(data)
+	for _, want := range []string{"Apps scanned: 2\n", "Puzzle            2      genres (2)\n", "Great Soundtrack  1      (ignored) (1)\n"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("the report does not contain %q:\n%s", want, report)
+		}
+	}
+}
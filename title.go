@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Typographic characters are written in ASCII, characters MediaWiki does not
+// allow in titles are replaced. The number sign would start a section link,
+// it is written as the music sharp sign which looks alike
+var titleReplacer = strings.NewReplacer(
+	"‘", "'", "’", "'", "‚", "'", "′", "'",
+	"“", `"`, "”", `"`, "„", `"`, "″", `"`,
+	" ", " ", "…", "...",
+	"[", "(", "]", ")", "{", "(", "}", ")", "<", "(", ">", ")",
+	"|", " - ", "#", "♯", "_", " ",
+)
+
+// Editions sold alongside the game, which share its article
+var editionRegex = regexp.MustCompile(`(?i)\s*[-:–—]?\s*\(?\b(definitive|game of the year|goty|complete|deluxe|digital deluxe|gold|ultimate|premium|collector's|standard)\s+edition\)?$`)
+
+var asciiReplacer = strings.NewReplacer(
+	"À", "A", "Á", "A", "Â", "A", "Ã", "A", "Ä", "A", "Å", "A", "Æ", "AE", "Ç", "C",
+	"È", "E", "É", "E", "Ê", "E", "Ë", "E", "Ì", "I", "Í", "I", "Î", "I", "Ï", "I",
+	"Ñ", "N", "Ò", "O", "Ó", "O", "Ô", "O", "Õ", "O", "Ö", "O", "Ø", "O", "Œ", "OE",
+	"Ù", "U", "Ú", "U", "Û", "U", "Ü", "U", "Ý", "Y", "ß", "ss",
+	"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a", "å", "a", "æ", "ae", "ç", "c",
+	"è", "e", "é", "e", "ê", "e", "ë", "e", "ì", "i", "í", "i", "î", "i", "ï", "i",
+	"ñ", "n", "ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o", "ø", "o", "œ", "oe",
+	"ù", "u", "ú", "u", "û", "u", "ü", "u", "ý", "y", "ÿ", "y",
+	"Ł", "L", "ł", "l", "Ś", "S", "ś", "s", "Ż", "Z", "ż", "z", "Ź", "Z", "ź", "z",
+	"Č", "C", "č", "c", "Š", "S", "š", "s", "Ž", "Z", "ž", "z", "Ř", "R", "ř", "r",
+	"–", "-", "—", "-",
+)
+
+// The PCGW page title of the game, along with a DISPLAYTITLE if the title
+// starts with a lowercase letter (MediaWiki capitalises it) and redirects
+// from other names the game is known by
+func ArticleTitle(name string) (title PageTitle) {
+	clean := cleanTitle(SanitiseName(name, true))
+	base := strings.TrimSpace(editionRegex.ReplaceAllString(clean, ""))
+	if len(base) == 0 {
+		base = clean
+	}
+
+	title.Title = capitaliseTitle(base)
+	if title.Title != base {
+		title.DisplayTitle = base
+	}
+
+	// The name with its trademark symbols and edition
+	candidates := []string{cleanTitle(name), clean}
+
+	// Without the leading article, PCGW keeps it in the title
+	if strings.HasPrefix(strings.ToLower(base), "the ") {
+		candidates = append(candidates, base[4:])
+	}
+
+	for _, candidate := range candidates {
+		candidates = append(candidates, asciiReplacer.Replace(candidate))
+	}
+	candidates = append(candidates, asciiReplacer.Replace(base))
+
+	for _, candidate := range candidates {
+		candidate = capitaliseTitle(strings.TrimSpace(candidate))
+		if len(candidate) != 0 && candidate != title.Title {
+			title.Redirects = appendUnique(title.Redirects, candidate)
+		}
+	}
+	return
+}
+
+func cleanTitle(name string) string {
+	return strings.Join(strings.Fields(titleReplacer.Replace(name)), " ")
+}
+
+func capitaliseTitle(title string) string {
+	first, size := utf8.DecodeRuneInString(title)
+	if first == utf8.RuneError {
+		return title
+	}
+	return string(unicode.ToUpper(first)) + title[size:]
+}
+
+func (game *Game) Title() PageTitle {
+	return ArticleTitle(game.Data.Name)
+}
+
+// The DISPLAYTITLE line written at the top of the article, if needed
+func (title PageTitle) DisplayTitleLine() string {
+	if len(title.DisplayTitle) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("{{DISPLAYTITLE:%s}}\n", title.DisplayTitle)
+}
+
+// Wikitext of the redirect pages, by their title
+func (title PageTitle) RedirectPages() map[string]string {
+	pages := make(map[string]string)
+	for _, redirect := range title.Redirects {
+		pages[redirect] = fmt.Sprintf("#REDIRECT [[%s]]\n", title.Title)
+	}
+	return pages
+}
+
+// Writes each redirect page to output/<appid>.redirects/<title>.txt
+func (title PageTitle) WriteRedirects(gameId string) error {
+	if len(title.Redirects) == 0 {
+		return nil
+	}
+
+	directory := fmt.Sprintf("output/%s.redirects", gameId)
+	os.RemoveAll(directory)
+	if err := os.Mkdir(directory, 0777); err != nil {
+		return err
+	}
+
+	for redirect, text := range title.RedirectPages() {
+		fileName := strings.NewReplacer("/", "_", "\\", "_", ":", "_", "*", "_", "?", "_", `"`, "_").Replace(redirect)
+		if err := os.WriteFile(fmt.Sprintf("%s/%s.txt", directory, fileName), []byte(text), 0777); err != nil {
+			return err
+		}
+	}
+	return nil
+}
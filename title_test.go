@@ -0,0 +1,41 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestArticleTitle(t *testing.T) {
+	tests := []struct {
+		name string
+		want PageTitle
+	}{
+		{"Portal 2", PageTitle{Title: "Portal 2"}},
+		{"Fallout: New Vegas", PageTitle{Title: "Fallout: New Vegas"}},
+		{"Half-Life 2 - Episode One", PageTitle{Title: "Half-Life 2 - Episode One"}},
+		{"Game #1", PageTitle{Title: "Game ♯1"}},
+		{"Hashtag # Adventure", PageTitle{Title: "Hashtag ♯ Adventure"}},
+		{"The Witcher® 3: Wild Hunt", PageTitle{Title: "The Witcher 3: Wild Hunt", Redirects: []string{"The Witcher® 3: Wild Hunt", "Witcher 3: Wild Hunt"}}},
+		{"DARK SOULS™ III - Deluxe Edition", PageTitle{Title: "DARK SOULS III", Redirects: []string{"DARK SOULS™ III - Deluxe Edition", "DARK SOULS III - Deluxe Edition"}}},
+		{"Pokémon [Red]", PageTitle{Title: "Pokémon (Red)", Redirects: []string{"Pokemon (Red)"}}},
+		{"eFootball", PageTitle{Title: "EFootball", DisplayTitle: "eFootball"}},
+	}
+
+	for _, test := range tests {
+		if got := ArticleTitle(test.name); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ArticleTitle(%q) = %+v, want %+v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestArticleTitleNoSubtitleRedirect(t *testing.T) {
+	for name, shorter := range map[string]string{
+		"Fallout: New Vegas":        "Fallout",
+		"Half-Life 2 - Episode One": "Half-Life 2",
+		"Portal 2: Deluxe Edition":  "Portal",
+	} {
+		if containsString(ArticleTitle(name).Redirects, shorter) {
+			t.Errorf("ArticleTitle(%q) redirects from '%s', the title of another game", name, shorter)
+		}
+	}
+}
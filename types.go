@@ -1,5 +1,12 @@
 package main
 
+import (
+	"encoding/xml"
+	"io"
+	"regexp"
+	"time"
+)
+
 type Game struct {
 	Success bool `json:"success"`
 	Data    Data `json:"data"`
@@ -45,6 +52,26 @@ type Data struct {
 	Vehicles     string                  `json:"-"` // Scraped from App Tags (Taxonomy on PCGW)
 	ArtStyles    string                  `json:"-"` // Scraped from App Tags (Taxonomy on PCGW)
 	Themes       string                  `json:"-"` // Scraped from App Tags (Taxonomy on PCGW)
+	AppTags      []string                `json:"-"` // Scraped from Steam Store
+	TagRows      map[string][]string     `json:"-"` // Taxonomy rows fed by each app tag
+	Monetization Monetization            `json:"-"` // Inferred from packages, DLCs and categories
+	InAppNotice  string                  `json:"-"` // Scraped from Steam Store
+
+	Microtransactions Microtransactions `json:"-"` // Detected from the store page, app tags and notices
+	UnknownStores     []string          `json:"-"` // Stores found on IsThereAnyDeal but missing from the store registry
+	DRMConflicts      []string          `json:"-"` // Disagreements found while resolving the Availability DRM
+	GogID             string            `json:"-"` // Found on the GOG.com catalogue
+	GogSideIDs        []string          `json:"-"` // GOG.com DLCs and editions
+	NewCompanies      []string          `json:"-"` // Developers and publishers without a Company: page on PCGW
+
+	Subscriptions        []Subscription `json:"-"` // Found in the subscription service catalogue
+	SubscriptionSnapshot string         `json:"-"` // Date of the subscription service catalogue
+
+	ExternalIDs map[string]string `json:"-"` // Infobox IDs resolved through Wikidata
+	IGDB        *IGDBRating       `json:"-"` // Found on IGDB through the Steam app ID
+
+	Sources      map[string]Source `json:"-"` // Where the data was fetched from and when, by source
+	Completeness *Completeness     `json:"-"` // Scored once the article is generated
 }
 
 type PackageGroup struct {
@@ -113,10 +140,560 @@ type LanguageData struct {
 type Store struct {
 	Platforms string
 	URL       string
+	DRM       []string // Reported by IsThereAnyDeal, if any
 }
 
 type ValidStore struct {
-	ScrapeName  string
-	DisplayName string
-	LinkToStrip string
+	ScrapeNames []string `json:"scrape_names"` // Names used by IsThereAnyDeal
+	DisplayName string   `json:"display_name"` // Name used by the Availability row
+	IDPatterns  []string `json:"id_patterns"`  // The first group is the ID used by the Availability row
+	DRM         string   `json:"drm"`
+	Platforms   string   `json:"platforms"` // Used when no platforms were scraped
+
+	patterns []*regexp.Regexp
+}
+
+type TagUsage struct {
+	Count int
+	Rows  map[string]int
+}
+
+type TaxonomyReport struct {
+	Apps int
+	Tags map[string]*TagUsage
+}
+
+type MonetizationType struct {
+	Param    string
+	Taxonomy string
+}
+
+type Monetization struct {
+	Text     map[string]string // Explanatory text for each Monetization template parameter
+	Comments map[string]string // Unclear cases, left as comments for review
+}
+
+type MicrotransactionSignal struct {
+	Param   string
+	Text    string
+	Pattern string
+}
+
+type Microtransactions struct {
+	Text map[string]string // Text (with the triggering snippet) for each Microtransactions template parameter
+}
+
+type Config struct {
+	ITAD          ITADConfig         `json:"itad"`
+	GOG           GOGConfig          `json:"gog"`
+	Wikidata      WikidataConfig     `json:"wikidata"`
+	IGDB          IGDBConfig         `json:"igdb"`
+	PCGW          PCGWConfig         `json:"pcgw"`
+	Companies     CompaniesConfig    `json:"companies"`
+	Subscriptions string             `json:"subscriptions"` // Path or URL of the subscription service catalogue
+	StoresFile    string             `json:"stores_file"`   // Overrides the embedded store registry if the file exists
+	SchemaFile    string             `json:"schema_file"`   // Overrides the embedded template schema if the file exists
+	Placeholders  bool               `json:"placeholders"`  // Writes placeholder rows (such as reception rows) when there is no data
+	Completeness  CompletenessConfig `json:"completeness"`
+}
+
+type CompletenessConfig struct {
+	StubBelow int            `json:"stub_below"` // Articles scoring less than this (out of 100) are marked as stubs
+	Sections  map[string]int `json:"sections"`   // Minimum score of each section, articles with a section below it are marked as stubs
+}
+
+type ITADConfig struct {
+	Key     string `json:"key"`
+	BaseURL string `json:"base_url"`
+	Country string `json:"country"`
+}
+
+type ITADLookup struct {
+	Found bool `json:"found"`
+	Game  struct {
+		ID    string `json:"id"`
+		Slug  string `json:"slug"`
+		Title string `json:"title"`
+	} `json:"game"`
+}
+
+type ITADInfo struct {
+	Reviews []ITADReview `json:"reviews"`
+}
+
+type ITADReview struct {
+	Score  int    `json:"score"`
+	Source string `json:"source"`
+	Count  int    `json:"count"`
+	URL    string `json:"url"`
+}
+
+type ITADPrices struct {
+	ID    string     `json:"id"`
+	Deals []ITADDeal `json:"deals"`
+}
+
+type ITADDeal struct {
+	Shop      ITADEntity   `json:"shop"`
+	URL       string       `json:"url"`
+	Platforms []ITADEntity `json:"platforms"`
+	DRM       []ITADEntity `json:"drm"`
+}
+
+type ITADEntity struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type DRMSignal struct {
+	Key     string
+	Kind    string
+	Pattern string
+}
+
+type GOGConfig struct {
+	Enabled    bool   `json:"enabled"`
+	CatalogURL string `json:"catalog_url"`
+	APIURL     string `json:"api_url"`
+}
+
+type GOGCatalog struct {
+	Products []GOGCatalogProduct `json:"products"`
+}
+
+type GOGCatalogProduct struct {
+	ID          string   `json:"id"`
+	Slug        string   `json:"slug"`
+	Title       string   `json:"title"`
+	ProductType string   `json:"productType"` // game or pack
+	Developers  []string `json:"developers"`
+	Publishers  []string `json:"publishers"`
+}
+
+type GOGProduct struct {
+	ID                         int64  `json:"id"`
+	Title                      string `json:"title"`
+	Slug                       string `json:"slug"`
+	ContentSystemCompatibility struct {
+		Windows bool `json:"windows"`
+		OSX     bool `json:"osx"`
+		Linux   bool `json:"linux"`
+	} `json:"content_system_compatibility"`
+	DLCs struct {
+		Products []struct {
+			ID int64 `json:"id"`
+		} `json:"products"`
+	} `json:"dlcs"`
+}
+
+type SubscriptionCatalogue struct {
+	Date     string                `json:"date"`
+	Services []SubscriptionService `json:"services"`
+}
+
+type SubscriptionService struct {
+	Name      string            `json:"name"`      // Name used by {{Store feature}}
+	Store     string            `json:"store"`     // Store of the Availability row
+	DRM       string            `json:"drm"`       // DRM of the Availability row
+	Platforms string            `json:"platforms"` // Platforms of the Availability row
+	Apps      []SubscriptionApp `json:"apps"`
+}
+
+type SubscriptionApp struct {
+	SteamAppID int64  `json:"steam_appid"`
+	ID         string `json:"id"` // ID used by the Availability row
+}
+
+type Subscription struct {
+	Service SubscriptionService
+	ID      string
+}
+
+type WikidataConfig struct {
+	Enabled  bool   `json:"enabled"`
+	Endpoint string `json:"endpoint"`
+}
+
+type WikidataProperty struct {
+	Field    string
+	Property string
+}
+
+type WikidataResult struct {
+	Results struct {
+		Bindings []map[string]struct {
+			Type  string `json:"type"`
+			Value string `json:"value"`
+		} `json:"bindings"`
+	} `json:"results"`
+}
+
+type IGDBConfig struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	AccessToken  string `json:"access_token"` // Skips requesting a token with the client secret
+	BaseURL      string `json:"base_url"`
+	TokenURL     string `json:"token_url"`
+}
+
+type IGDBToken struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+type IGDBExternalGame struct {
+	ID   int64    `json:"id"`
+	Game IGDBGame `json:"game"`
+}
+
+type IGDBGame struct {
+	ID               int64   `json:"id"`
+	Name             string  `json:"name"`
+	Slug             string  `json:"slug"`
+	Rating           float64 `json:"rating"`
+	AggregatedRating float64 `json:"aggregated_rating"`
+}
+
+type IGDBRating struct {
+	Slug  string
+	Score int
+}
+
+type PCGWConfig struct {
+	APIURL        string `json:"api_url"`
+	CheckExisting bool   `json:"check_existing"` // Looks for an existing article before generating
+	StopIfExists  bool   `json:"stop_if_exists"` // Stops instead of warning if an article exists
+	BotUsername   string `json:"bot_username"`   // Bot password login (Special:BotPasswords) used by publish
+	BotPassword   string `json:"bot_password"`
+}
+
+type CompaniesConfig struct {
+	Enabled     bool   `json:"enabled"`      // Checks the names against the Company: pages of PCGW
+	AliasesFile string `json:"aliases_file"` // Maps names used by Steam to the PCGW page name
+}
+
+type PCGWNamespaces struct {
+	Query struct {
+		Namespaces map[string]struct {
+			ID        int    `json:"id"`
+			Name      string `json:"name"`
+			Canonical string `json:"canonical"`
+		} `json:"namespaces"`
+	} `json:"query"`
+}
+
+type PCGWAllPages struct {
+	Continue map[string]string `json:"continue"`
+	Query    struct {
+		AllPages []struct {
+			Title string `json:"title"`
+		} `json:"allpages"`
+	} `json:"query"`
+}
+
+type PCGWCargoQuery struct {
+	CargoQuery []struct {
+		Title map[string]string `json:"title"`
+	} `json:"cargoquery"`
+}
+
+type PCGWQuery struct {
+	Query struct {
+		Pages map[string]PCGWPage `json:"pages"`
+	} `json:"query"`
+}
+
+type PCGWAPIError struct {
+	Code string `json:"code"`
+	Info string `json:"info"`
+}
+
+type PCGWTokens struct {
+	Error *PCGWAPIError `json:"error"`
+	Query struct {
+		Tokens struct {
+			LoginToken string `json:"logintoken"`
+			CSRFToken  string `json:"csrftoken"`
+		} `json:"tokens"`
+	} `json:"query"`
+}
+
+type PCGWLogin struct {
+	Error *PCGWAPIError `json:"error"`
+	Login struct {
+		Result string `json:"result"`
+		Reason string `json:"reason"`
+	} `json:"login"`
+}
+
+type PCGWEdit struct {
+	Error *PCGWAPIError `json:"error"`
+	Edit  struct {
+		Result   string `json:"result"`
+		Title    string `json:"title"`
+		NewRevID int64  `json:"newrevid"`
+	} `json:"edit"`
+}
+
+type PCGWUpload struct {
+	Error  *PCGWAPIError `json:"error"`
+	Upload struct {
+		Result   string                 `json:"result"`
+		Filename string                 `json:"filename"`
+		Warnings map[string]interface{} `json:"warnings"`
+	} `json:"upload"`
+}
+
+type PCGWPage struct {
+	PageID  int64       `json:"pageid"`
+	Title   string      `json:"title"`
+	Missing interface{} `json:"missing"`
+	Invalid interface{} `json:"invalid"`
+}
+
+type PCGWRevisionQuery struct {
+	Query struct {
+		Pages []struct {
+			Title     string `json:"title"`
+			Missing   bool   `json:"missing"`
+			Revisions []struct {
+				Slots struct {
+					Main struct {
+						Content string `json:"content"`
+					} `json:"main"`
+				} `json:"slots"`
+			} `json:"revisions"`
+		} `json:"pages"`
+	} `json:"query"`
+}
+
+// A template call found in wikitext, offsets point into the scanned text
+type WikiTemplate struct {
+	Name   string
+	Start  int // Offset of the opening {{
+	End    int // Offset right after the closing }}
+	Params []WikiParam
+}
+
+// Positional parameters are named after their position ("1", "2", ...) like
+// MediaWiki does
+type WikiParam struct {
+	Name       string
+	Start      int    // Offset right after the |
+	Value      string // Trimmed, without comments
+	ValueStart int
+	ValueEnd   int
+}
+
+// Wikitext is either plain text (comments included) or a template call,
+// serializing the nodes gives back the parsed text byte-for-byte
+type WikiNode struct {
+	Text     string
+	Template *WikiTemplateNode
+}
+
+type WikiTemplateNode struct {
+	Name    string // Trimmed, without comments
+	NameRaw string
+	Args    []WikiArg
+}
+
+type WikiArg struct {
+	Name    string // Positional arguments are named after their position
+	NameRaw string // Text before the =, empty for positional arguments
+	Named   bool
+	Value   []WikiNode
+}
+
+// The data read back from an article, using the same types the generator
+// works from
+type Article struct {
+	Infobox            map[string]string            `json:"infobox"` // Plain infobox fields
+	Developers         []string                     `json:"developers"`
+	Publishers         []string                     `json:"publishers"`
+	Engines            []string                     `json:"engines"`
+	ReleaseDates       map[string]string            `json:"release_dates"`
+	Reception          map[string]Rating            `json:"reception"` // The URL holds the link parameter
+	Taxonomy           map[string]string            `json:"taxonomy"`
+	Stores             map[string]Store             `json:"stores"` // The URL holds the ID parameter
+	Languages          map[string]LanguageData      `json:"languages"`
+	SystemRequirements map[string]map[string]string `json:"system_requirements"`
+	Sections           map[string]map[string]string `json:"sections"` // API, Audio, Input, Network, Video, Middleware...
+}
+
+// Templates compared by the update command, matched by their key parameter
+type UpdateTemplate struct {
+	Name      string
+	Key       string
+	Fields    []string // Every parameter but the key if empty
+	Separator string   // Written before a template missing from the article
+}
+
+type WikiEdit struct {
+	Start int
+	End   int
+	Text  string
+}
+
+type DiffLine struct {
+	Kind byte // ' ', '-' or '+'
+	Text string
+}
+
+// Parameters allowed in each template, mapped to "text" (anything goes) or
+// to the name of an enum. The "*" parameter matches any other parameter
+type TemplateSchema struct {
+	Enums     map[string][]string          `json:"enums"`
+	Templates map[string]map[string]string `json:"templates"`
+}
+
+type LintIssue struct {
+	Line     int
+	Template string
+	Message  string
+}
+
+type CompanyResolver struct {
+	Pages   map[string]string // Company: page names by their normalised name
+	Aliases map[string]string // PCGW page names by the normalised name used elsewhere
+}
+
+type PageTitle struct {
+	Title        string
+	DisplayTitle string   // Only set if the title cannot show the name as is
+	Redirects    []string // Other names the game is known by
+}
+
+// MediaWiki XML export format, as read by Special:Import
+type XMLDump struct {
+	XMLName xml.Name  `xml:"mediawiki"`
+	Xmlns   string    `xml:"xmlns,attr"`
+	Version string    `xml:"version,attr"`
+	Lang    string    `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Pages   []XMLPage `xml:"page"`
+}
+
+type XMLPage struct {
+	Title     string       `xml:"title"`
+	Namespace int          `xml:"ns"`
+	Redirect  *XMLRedirect `xml:"redirect,omitempty"`
+	Revision  XMLRevision  `xml:"revision"`
+}
+
+type XMLRedirect struct {
+	Title string `xml:"title,attr"`
+}
+
+type XMLRevision struct {
+	Timestamp   string         `xml:"timestamp"`
+	Contributor XMLContributor `xml:"contributor"`
+	Comment     string         `xml:"comment"`
+	Model       string         `xml:"model"`
+	Format      string         `xml:"format"`
+	Text        XMLText        `xml:"text"`
+	SHA1        string         `xml:"sha1"`
+}
+
+type XMLContributor struct {
+	Username string `xml:"username"`
+}
+
+type XMLText struct {
+	Space string `xml:"http://www.w3.org/XML/1998/namespace space,attr"`
+	Bytes int    `xml:"bytes,attr"`
+	Text  string `xml:",innerxml"` // Escaped by the exporter, which keeps the line breaks
+}
+
+type ReviewItem struct {
+	Line     int    `json:"line"` // 0 if the item is not tied to a line
+	Template string `json:"template,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Rule     string `json:"rule"`
+	Message  string `json:"message"`
+}
+
+type ReviewReport struct {
+	Title   string       `json:"title"`
+	Article string       `json:"article"`
+	Items   []ReviewItem `json:"items"`
+}
+
+type Source struct {
+	Name      string    `json:"name"`
+	URL       string    `json:"url,omitempty"`
+	Retrieved time.Time `json:"retrieved"`
+}
+
+// A template parameter filled from a source. Key only matches rows whose
+// first parameter is the given value, Cite adds a reference to the value
+// (or to Notes, the notes parameter next to it)
+type Provenance struct {
+	Template string
+	Param    string
+	Key      string
+	Source   string
+	Cite     bool
+	Notes    string
+}
+
+type SourcedValue struct {
+	Line      int       `json:"line"`
+	Template  string    `json:"template"`
+	Field     string    `json:"field"`
+	Value     string    `json:"value"`
+	Source    string    `json:"source"`
+	Retrieved time.Time `json:"retrieved"`
+}
+
+// Templates (a trailing * matches any suffix) and fields counted towards a
+// section of the completeness score, all fields but notes if none are given.
+// Sections whose fields only apply to some games (Any) are complete once one
+// of them is filled
+type CompletenessSection struct {
+	Name      string
+	Templates []string
+	Fields    []string
+	Any       bool
+}
+
+type SectionScore struct {
+	Name   string `json:"name"`
+	Filled int    `json:"filled"`
+	Total  int    `json:"total"`
+	Score  int    `json:"score"`
+}
+
+type Completeness struct {
+	Title    string         `json:"title"`
+	Score    int            `json:"score"`
+	Stub     bool           `json:"stub"`
+	Reasons  []string       `json:"reasons,omitempty"`
+	Sections []SectionScore `json:"sections"`
+}
+
+// Diagnostics are written to Output (stderr), so the results of a command
+// (such as the article) can be written to stdout on their own
+type Logger struct {
+	Level  LogLevel
+	JSON   bool
+	Output io.Writer
+	Fields map[string]string // Added to every entry, such as the app being processed
+}
+
+// Numbered progress through a fixed list of steps
+type Progress struct {
+	Steps   []string
+	Current int
+}
+
+type SteamSearch struct {
+	Total int               `json:"total"`
+	Items []SteamSearchItem `json:"items"`
+}
+
+type SteamSearchItem struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+	ID   int64  `json:"id"`
 }
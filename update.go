@@ -0,0 +1,354 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var updateTemplates = []UpdateTemplate{
+	{Name: "Infobox game/row/date", Key: "1", Fields: []string{"2"}, Separator: "\n"},
+	{Name: "Availability/row", Key: "1", Fields: []string{"2", "3", "6"}, Separator: "\n"},
+	{Name: "L10n/switch", Key: "language", Fields: []string{"interface", "audio", "subtitles"}, Separator: "\n"},
+	{Name: "System requirements", Key: "OSfamily", Separator: "\n\n"},
+}
+
+// Diffs a freshly generated article against an existing PCGW article, only
+// filling values the article leaves empty unless -force is given
+func RunUpdate(args []string) {
+	if code := updateArticle(args); code != 0 {
+		os.Exit(code)
+	}
+}
+
+// Exit code 1 if the article could not be compared, 2 on invalid arguments
+func updateArticle(args []string) int {
+	flags := flag.NewFlagSet("update", flag.ContinueOnError)
+	patch := flags.Bool("patch", false, "write the patched wikitext to output/<appid>.updated.txt")
+	force := flags.Bool("force", false, "overwrite values which differ from the article instead of reporting them")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: update [-patch] [-force] <appid> [page title or wikitext file]")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return 0
+	} else if err != nil {
+		return 2
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	gameId, err := ResolveAppID(flags.Arg(0), false)
+	if err != nil {
+		Log.Errorf("%s", err)
+		return 1
+	}
+	article, name, err := readArticle(gameId, strings.Join(flags.Args()[1:], " "))
+	if err != nil {
+		Log.Errorf("Failed to read the existing article... (%s)", err)
+		return 1
+	}
+
+	game, err := LoadGame(gameId)
+	if err != nil {
+		Log.Errorf("%s", err)
+		return 1
+	}
+
+	edits, conflicts := CompareArticle(article, GenerateArticle(gameId, &game), *force)
+	updated, err := ApplyEdits(article, edits)
+	if err != nil {
+		Log.Errorf("Failed to apply the updates... (%s)", err)
+		return 1
+	}
+
+	diff := UnifiedDiff(article, updated, name)
+	if len(diff) == 0 {
+		Log.Infof("'%s' is up to date with the Steam data", name)
+	} else {
+		fmt.Print(diff)
+		if err = os.WriteFile(fmt.Sprintf("output/%s.diff", gameId), []byte(diff), 0777); err != nil {
+			Log.Warnf("Failed to write the diff...")
+		}
+	}
+
+	for _, conflict := range conflicts {
+		Log.Warnf("Not updated: %s", conflict)
+	}
+
+	if *patch {
+		if err = os.WriteFile(fmt.Sprintf("output/%s.updated.txt", gameId), []byte(updated), 0777); err != nil {
+			Log.Errorf("Failed to write the patched article...")
+			return 1
+		}
+		Log.Infof("Patched article written to output/%s.updated.txt", gameId)
+	}
+	return 0
+}
+
+// Reads the article from a local file if the source is one, otherwise fetches
+// the page (looked up through the app ID if no title is given)
+func readArticle(gameId, source string) (text string, name string, err error) {
+	if len(source) != 0 {
+		if _, statErr := os.Stat(source); statErr == nil {
+			var data []byte
+			data, err = os.ReadFile(source)
+			return string(data), filepath.Base(source), err
+		}
+	}
+
+	client := NewPCGWClient(AppConfig.PCGW)
+	name = source
+	if len(name) == 0 {
+		var pages []string
+		if pages, err = client.FindBySteamID(gameId); err != nil {
+			return
+		}
+
+		if len(pages) == 0 {
+			err = fmt.Errorf("no PCGW article found for app ID %s", gameId)
+			return
+		} else if len(pages) > 1 {
+			Log.Warnf("Found multiple PCGW articles for the app ID (%s), using '%s'...", strings.Join(pages, ", "), pages[0])
+		}
+		name = pages[0]
+	}
+
+	Log.Infof("Fetching the wikitext of '%s'...", name)
+	text, err = client.Wikitext(name)
+	return
+}
+
+// Edits bringing the article in line with the generated one. Values which are
+// set on both sides but differ are reported instead, as they may be edits
+// made by humans
+func CompareArticle(article, generated string, force bool) (edits []WikiEdit, conflicts []string) {
+	current := ParseTemplates(article)
+	fresh := ParseTemplates(generated)
+
+	for _, kind := range updateTemplates {
+		var last *WikiTemplate
+		for i := range current {
+			if templateNameIs(current[i].Name, kind.Name) {
+				last = &current[i]
+			}
+		}
+
+		for i := range fresh {
+			if !templateNameIs(fresh[i].Name, kind.Name) {
+				continue
+			}
+
+			key := fresh[i].Param(kind.Key)
+			if key == nil || len(key.Value) == 0 {
+				continue
+			}
+
+			existing := kind.find(current, key.Value)
+			if existing != nil {
+				fieldEdits, fieldConflicts := kind.compare(article, existing, &fresh[i], key.Value, force)
+				edits = append(edits, fieldEdits...)
+				conflicts = append(conflicts, fieldConflicts...)
+			} else if last != nil {
+				edits = append(edits, WikiEdit{Start: last.End, End: last.End, Text: kind.Separator + generated[fresh[i].Start:fresh[i].End]})
+			} else {
+				conflicts = append(conflicts, fmt.Sprintf("{{%s|%s}} is missing, the article has no {{%s}} to add it next to", kind.Name, key.Value, kind.Name))
+			}
+		}
+	}
+	return
+}
+
+func (kind UpdateTemplate) find(templates []WikiTemplate, key string) *WikiTemplate {
+	for i := range templates {
+		if !templateNameIs(templates[i].Name, kind.Name) {
+			continue
+		}
+
+		if param := templates[i].Param(kind.Key); param != nil && normaliseValue(param.Value) == normaliseValue(key) {
+			return &templates[i]
+		}
+	}
+	return nil
+}
+
+func (kind UpdateTemplate) compare(article string, existing, fresh *WikiTemplate, key string, force bool) (edits []WikiEdit, conflicts []string) {
+	fields := kind.Fields
+	if len(fields) == 0 {
+		for _, param := range fresh.Params {
+			if param.Name != kind.Key {
+				fields = append(fields, param.Name)
+			}
+		}
+	}
+
+	for _, field := range fields {
+		freshParam := fresh.Param(field)
+		// Never blank out a value the article has
+		if freshParam == nil || len(freshParam.Value) == 0 {
+			continue
+		}
+		value := freshParam.Value
+
+		param := existing.Param(field)
+		if param == nil {
+			edits = append(edits, WikiEdit{Start: existing.End - 2, End: existing.End - 2, Text: missingParam(existing, field, value)})
+			continue
+		}
+
+		if normaliseValue(param.Value) == normaliseValue(value) {
+			continue
+		}
+
+		if force || len(param.Value) == 0 || strings.EqualFold(param.Value, "unknown") {
+			edits = append(edits, param.Replace(article, value))
+		} else {
+			conflicts = append(conflicts, fmt.Sprintf("{{%s|%s}} %s is '%s' on the article, Steam has '%s'", kind.Name, key, field, param.Value, value))
+		}
+	}
+	return
+}
+
+// Text adding the parameter before the closing braces of the template,
+// positional ones are padded with empty parameters
+func missingParam(template *WikiTemplate, name, value string) string {
+	position, err := strconv.Atoi(name)
+	if err != nil {
+		return fmt.Sprintf("|%s = %s\n", name, value)
+	}
+
+	count := 0
+	for _, param := range template.Params {
+		if _, err := strconv.Atoi(param.Name); err == nil {
+			count++
+		}
+	}
+	return strings.Repeat("| ", position-count-1) + "| " + value + " "
+}
+
+func normaliseValue(value string) string {
+	return strings.ToLower(strings.Join(strings.Fields(value), " "))
+}
+
+// Line-based unified diff with 3 lines of context, empty if nothing changed
+func UnifiedDiff(before, after, name string) string {
+	lines := diffLines(splitLines(before), splitLines(after))
+
+	// Line numbers of both sides before each diff line
+	oldLine := make([]int, len(lines)+1)
+	newLine := make([]int, len(lines)+1)
+	for i, line := range lines {
+		oldLine[i+1], newLine[i+1] = oldLine[i], newLine[i]
+		if line.Kind != '+' {
+			oldLine[i+1]++
+		}
+		if line.Kind != '-' {
+			newLine[i+1]++
+		}
+	}
+
+	var output strings.Builder
+	for i := 0; i < len(lines); {
+		change := i
+		for change < len(lines) && lines[change].Kind == ' ' {
+			change++
+		}
+		if change == len(lines) {
+			break
+		}
+
+		// Changes less than 7 lines apart share a hunk
+		last := change
+		for j := change; j < len(lines) && j-last <= 6; j++ {
+			if lines[j].Kind != ' ' {
+				last = j
+			}
+		}
+
+		start := change - 3
+		if start < i {
+			start = i
+		}
+		end := last + 4
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		if output.Len() == 0 {
+			output.WriteString(fmt.Sprintf("--- a/%s\n+++ b/%s\n", name, name))
+		}
+
+		output.WriteString(fmt.Sprintf("@@ -%s +%s @@\n",
+			hunkRange(oldLine[start], oldLine[end]-oldLine[start]),
+			hunkRange(newLine[start], newLine[end]-newLine[start])))
+		for _, line := range lines[start:end] {
+			output.WriteString(string(line.Kind) + line.Text + "\n")
+		}
+		i = end
+	}
+	return output.String()
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func splitLines(text string) []string {
+	if len(text) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// Longest common subsequence of the lines, as kept, removed and added lines
+func diffLines(before, after []string) (lines []DiffLine) {
+	lcs := make([][]int, len(before)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(after)+1)
+	}
+
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(before) && j < len(after) {
+		if before[i] == after[j] {
+			lines = append(lines, DiffLine{Kind: ' ', Text: before[i]})
+			i++
+			j++
+		} else if lcs[i+1][j] >= lcs[i][j+1] {
+			lines = append(lines, DiffLine{Kind: '-', Text: before[i]})
+			i++
+		} else {
+			lines = append(lines, DiffLine{Kind: '+', Text: after[j]})
+			j++
+		}
+	}
+
+	for ; i < len(before); i++ {
+		lines = append(lines, DiffLine{Kind: '-', Text: before[i]})
+	}
+	for ; j < len(after); j++ {
+		lines = append(lines, DiffLine{Kind: '+', Text: after[j]})
+	}
+	return
+}
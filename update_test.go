@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const updateArticleText = `{{Infobox game/row/date|Windows|unknown}}
+{{Availability/row| Steam | 620 | Steam | | | Windows }}
+{{Availability/row| GOG.com | portal_2 | DRM-free | | | Windows }}
+{{L10n/switch
+|language  = English
+|interface = true
+|audio     = 
+|subtitles = false
+}}
+`
+
+const updateGeneratedText = `{{Infobox game/row/date|Windows|April 18, 2011}}
+{{Availability/row| Steam | 620 | Steam | | | Windows, OS X }}
+{{Availability/row| GOG.com | portal-2 | DRM-free | | | Windows }}
+{{Availability/row| Humble | portal-2 | Steam | | | Windows }}
+{{L10n/switch
+|language  = English
+|interface = true
+|audio     = true
+|subtitles = true
+}}
+{{L10n/switch
+|language  = French
+|interface = true
+|audio     = false
+|subtitles = true
+}}
+`
+
+func TestCompareArticle(t *testing.T) {
+	edits, conflicts := CompareArticle(updateArticleText, updateGeneratedText, false)
+	updated, err := ApplyEdits(updateArticleText, edits)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Unknown and empty values are filled, missing rows and languages are
+	// added after the existing ones, differing values are kept
+	want := `{{Infobox game/row/date|Windows|April 18, 2011}}
+{{Availability/row| Steam | 620 | Steam | | | Windows }}
+{{Availability/row| GOG.com | portal_2 | DRM-free | | | Windows }}
+{{Availability/row| Humble | portal-2 | Steam | | | Windows }}
+{{L10n/switch
+|language  = English
+|interface = true
+|audio     = true
+|subtitles = false
+}}
+{{L10n/switch
+|language  = French
+|interface = true
+|audio     = false
+|subtitles = true
+}}
+`
+	if updated != want {
+		t.Errorf("updated article:\n%s\nwant:\n%s", updated, want)
+	}
+
+	wantConflicts := []string{
+		"{{Availability/row|Steam}} 6 is 'Windows' on the article, Steam has 'Windows, OS X'",
+		"{{Availability/row|GOG.com}} 2 is 'portal_2' on the article, Steam has 'portal-2'",
+		"{{L10n/switch|English}} subtitles is 'false' on the article, Steam has 'true'",
+	}
+	if strings.Join(conflicts, "\n") != strings.Join(wantConflicts, "\n") {
+		t.Errorf("conflicts = %q, want %q", conflicts, wantConflicts)
+	}
+}
+
+func TestCompareArticleForce(t *testing.T) {
+	edits, conflicts := CompareArticle(updateArticleText, updateGeneratedText, true)
+	updated, err := ApplyEdits(updateArticleText, edits)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updated != updateGeneratedText {
+		t.Errorf("updated article:\n%s\nwant the generated one:\n%s", updated, updateGeneratedText)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("conflicts with -force: %q", conflicts)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	var lines []string
+	for i := 1; i <= 20; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	text := strings.Join(lines, "\n") + "\n"
+	change := func(numbers ...int) string {
+		changed := text
+		for _, n := range numbers {
+			changed = strings.Replace(changed, fmt.Sprintf("line %d\n", n), fmt.Sprintf("changed %d\n", n), 1)
+		}
+		return changed
+	}
+
+	tests := []struct {
+		name, before, after string
+		hunks               []string
+	}{
+		{"unchanged", text, text, nil},
+		{"one change", text, change(5), []string{"@@ -2,7 +2,7 @@"}},
+		{"changes sharing a hunk", text, change(5, 10), []string{"@@ -2,12 +2,12 @@"}},
+		{"separate hunks", text, change(5, 18), []string{"@@ -2,7 +2,7 @@", "@@ -15,6 +15,6 @@"}},
+		{"first line", text, change(1), []string{"@@ -1,4 +1,4 @@"}},
+		{"added lines", text, strings.Replace(text, "line 10\n", "line 10\nnew\nnew\n", 1), []string{"@@ -8,6 +8,8 @@"}},
+		{"removed line", text, strings.Replace(text, "line 10\n", "", 1), []string{"@@ -7,7 +7,6 @@"}},
+		{"new file", "", "line 1\n", []string{"@@ -0,0 +1,1 @@"}},
+		{"emptied file", "line 1\n", "", []string{"@@ -1,1 +0,0 @@"}},
+	}
+
+	for _, test := range tests {
+		diff := UnifiedDiff(test.before, test.after, "Portal 2")
+		if len(test.hunks) == 0 {
+			if len(diff) != 0 {
+				t.Errorf("%s: diff = %q, want none", test.name, diff)
+			}
+			continue
+		}
+
+		if !strings.HasPrefix(diff, "--- a/Portal 2\n+++ b/Portal 2\n") {
+			t.Errorf("%s: diff has no file header:\n%s", test.name, diff)
+		}
+
+		var hunks []string
+		for _, line := range strings.Split(diff, "\n") {
+			if strings.HasPrefix(line, "@@") {
+				hunks = append(hunks, line)
+			}
+		}
+		if strings.Join(hunks, "\n") != strings.Join(test.hunks, "\n") {
+			t.Errorf("%s: hunks = %q, want %q\n%s", test.name, hunks, test.hunks, diff)
+		}
+	}
+}
+
+func TestUpdateArguments(t *testing.T) {
+	chdirTemp(t)
+
+	config := AppConfig
+	AppConfig.PCGW.APIURL = "http://127.0.0.1:0"
+	defer func() { AppConfig = config }()
+
+	if code := updateArticle(nil); code != 2 {
+		t.Errorf("update without arguments exited with %d, want 2", code)
+	}
+	// Neither a file nor a reachable page
+	if code := updateArticle([]string{"620", "Portal 2"}); code != 1 {
+		t.Errorf("update without an article exited with %d, want 1", code)
+	}
+}
@@ -56,12 +56,15 @@ func UnmarshalGame(data []byte) (result Game, err error) {
 	result = Game(tempResult[gameId])
 	result.Data.Ratings = make(map[string]Rating)
 	result.Data.Stores = make(map[string]Store)
+	result.addSource(SOURCE_STEAM_API, "Steam API app details for "+SanitiseName(result.Data.Name, true), API_LINK+gameId, cacheTime("cache/"+gameId+".json"))
 
 	var scrapeData []byte
 	scrapeData, err = os.ReadFile("cache/" + gameId + ".html")
 	if err != nil {
-		fmt.Printf("Failed to read scraped Steam page data")
+		Log.Warnf("Failed to read scraped Steam page data")
 	} else {
+		result.addSource(SOURCE_STEAM_STORE, SanitiseName(result.Data.Name, true)+" on Steam", fmt.Sprintf(STORE_LINK, gameId), cacheTime("cache/"+gameId+".html"))
+
 		franchiseNames := regexp.MustCompile(`<div class="dev_row">\s*<b>Franchise:</b>\s*<a href=".*">([^<]+)</a>\s*</div>`).FindStringSubmatch(string(scrapeData))
 		if len(franchiseNames) > 1 {
 			franchiseName := RemoveTags(html.UnescapeString(franchiseNames[0]), "")
@@ -70,43 +73,79 @@ func UnmarshalGame(data []byte) (result Game, err error) {
 			result.SetFranchise(franchiseName)
 		}
 
-		dirtyTags := regexp.MustCompile(`<a href=".+" class="app_tag" style=".+">\s+(.+)\s+<\/a>{1,}`).FindAllStringSubmatch(string(scrapeData), 50)
-		var appTags []string
-		for _, tag := range dirtyTags {
-			cleanTag := html.UnescapeString(tag[1])
-			cleanTag = strings.Replace(cleanTag, "+", "", 1)
-			cleanTag = strings.Replace(cleanTag, "Point & Click", "Point and Select", 1)
-			cleanTag = strings.TrimSpace(cleanTag)
+		result.SetTaxonomy(ScrapeAppTags(string(scrapeData)))
+
+		result.Data.InAppNotice = scrapeInAppNotice(string(scrapeData))
+	}
 
-			appTags = append(appTags, cleanTag)
+	// Is There Any Deals
+	result.loadITAD(NewITADClient(AppConfig.ITAD), gameId)
+
+	// Subscription gaming services
+	if len(AppConfig.Subscriptions) != 0 {
+		catalogue, optionalErr := LoadSubscriptionCatalogue(AppConfig.Subscriptions)
+		if optionalErr == nil {
+			Log.Infof("Using the subscription service catalogue from %s", catalogue.Date)
+			result.findSubscriptions(catalogue, gameId)
+
+			retrieved := cacheTime(AppConfig.Subscriptions)
+			if retrieved.IsZero() {
+				retrieved = time.Now()
+			}
+			result.addSource(SOURCE_SUBSCRIPTIONS, "Subscription service catalogue", AppConfig.Subscriptions, retrieved)
+		} else if !os.IsNotExist(optionalErr) {
+			Log.Warnf("Failed to load the subscription service catalogue... (%s)", optionalErr)
 		}
+	}
 
-		result.SetPacing(appTags)
-		result.SetPerspective(appTags)
-		result.SetControls(appTags)
-		result.SetGenres(appTags)
-		result.SetSports(appTags)
-		result.SetVehicles(appTags)
-		result.SetArtStyles(appTags)
-		result.SetThemes(appTags)
+	// Wikidata
+	if AppConfig.Wikidata.Enabled {
+		if optionalErr := result.fetchWikidata(NewWikidataClient(AppConfig.Wikidata), gameId); optionalErr != nil {
+			Log.Warnf("Failed to resolve the external IDs through Wikidata... (%s)", optionalErr)
+		} else {
+			result.addSource(SOURCE_WIKIDATA, "Wikidata", result.wikidataURL(gameId), cacheTime("cache/"+gameId+".wikidata.json"))
+		}
 	}
 
-	// Is There Any Deals
-	response, optionalErr := makeRequest(fmt.Sprintf("https://isthereanydeal.com/steam/app/%s", gameId))
-	if optionalErr = checkRequest(response, optionalErr); optionalErr == nil {
-		defer response.Body.Close()
-		body, _ := parseResponseToBody(response)
-		htmlString := string(body)
+	// IGDB
+	if len(AppConfig.IGDB.ClientID) != 0 {
+		if optionalErr := result.fetchIGDB(NewIGDBClient(AppConfig.IGDB), gameId); optionalErr != nil {
+			Log.Warnf("Failed to find the game on IGDB... (%s)", optionalErr)
+		} else {
+			result.addSource(SOURCE_IGDB, SanitiseName(result.Data.Name, true)+" on IGDB", fmt.Sprintf(IGDB_LINK, result.Data.IGDB.Slug), time.Now())
+		}
+	}
 
-		result.parseReviews(htmlString)
-		result.parseAvailability(htmlString)
-	} else {
-		fmt.Println("Failed to scrape IsThereAnyDeals page...")
+	// GOG.com
+	if AppConfig.GOG.Enabled {
+		if optionalErr := result.fetchGOG(NewGOGClient(AppConfig.GOG)); optionalErr != nil {
+			Log.Warnf("Failed to find the game on GOG.com... (%s)", optionalErr)
+		} else {
+			result.addSource(SOURCE_GOG, "GOG.com", "https://www.gog.com/", time.Now())
+		}
 	}
 
+	// Company pages
+	result.resolveCompanies(LoadCompanyResolver(AppConfig.Companies, NewPCGWClient(AppConfig.PCGW)))
+
 	return
 }
 
+func (game *Game) scrapeITAD(link string) {
+	response, err := makeRequest(link)
+	if err = checkRequest(response, err); err != nil {
+		Log.Warnf("Failed to scrape IsThereAnyDeals page...")
+		return
+	}
+	defer response.Body.Close()
+
+	body, _ := parseResponseToBody(response)
+	htmlString := string(body)
+
+	game.parseReviews(htmlString)
+	game.parseAvailability(htmlString)
+}
+
 func makeRequest(url string) (*http.Response, error) {
 	client := &http.Client{}
 	getData := strings.NewReader("")
@@ -137,9 +176,9 @@ func createCache(gameId string, apiBody []byte, scrapeBody []byte) (err error) {
 
 func checkRequest(response *http.Response, err error) error {
 	if err != nil {
-		fmt.Printf("Failed to connect to the '%v'... (error: %s)\n", response.Request.URL, err)
+		Log.Warnf("Failed to connect... (error: %s)", err)
 	} else if response.StatusCode != http.StatusOK {
-		fmt.Printf("Failed to connect to the '%v'... (HTTP code: %d)\n", response.Request.URL, response.StatusCode)
+		Log.Warnf("Failed to connect to the '%v'... (HTTP code: %d)", response.Request.URL, response.StatusCode)
 		err = errors.New("status code not OK")
 	}
 
@@ -149,7 +188,7 @@ func checkRequest(response *http.Response, err error) error {
 func parseResponseToBody(response *http.Response) (body []byte, err error) {
 	body, err = io.ReadAll(response.Body)
 	if err != nil {
-		fmt.Println("An error occurred while attempting to parse the response body...")
+		Log.Warnf("An error occurred while attempting to parse the response body...")
 	}
 	return
 }
@@ -192,14 +231,14 @@ func fetchGame(gameId string) (err error) {
 		defer optionalResponse.Body.Close()
 		scrapeBody, _ = parseResponseToBody(optionalResponse)
 	} else {
-		fmt.Println("Failed to scrape Steam Store page...")
+		Log.Warnf("Failed to scrape Steam Store page...")
 	}
 
 	err = createCache(gameId, apiBody, scrapeBody)
 	if err != nil {
-		fmt.Println("Failed to create the cache, but continuing the process...")
+		Log.Warnf("Failed to create the cache, but continuing the process...")
 	} else {
-		fmt.Println("Cached!")
+		Log.Debugf("Cached!")
 	}
 
 	return err
@@ -212,12 +251,12 @@ func ParseGame(gameId string) (body []byte, err error) {
 	fileName := fmt.Sprintf("cache/%s.json", gameId)
 
 	if doesCacheExistOrLatest(fileName) {
-		fmt.Println("Found cache...")
+		Log.Debugf("Found cache...")
 		body, err = os.ReadFile(fileName)
 		return
 	}
 
-	fmt.Println("Did not find game cache or cache is older than 7 days...")
+	Log.Infof("Did not find game cache or cache is older than 7 days...")
 
 	err = fetchGame(gameId)
 	if err == nil {
@@ -227,9 +266,19 @@ func ParseGame(gameId string) (body []byte, err error) {
 	return body, err
 }
 
+// Shared by every prompt, a reader per prompt would lose the lines buffered
+// by the previous one
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// Returned by TakeInput for an empty line, which can be asked again unlike
+// read errors
+var errEmptyInput = errors.New("invalid input")
+
 func TakeInput() (string, error) {
-	reader := bufio.NewReader(os.Stdin)
-	text, _ := reader.ReadString('\n')
+	text, err := stdinReader.ReadString('\n')
+	if err != nil && len(text) == 0 {
+		return "", err
+	}
 
 	// For Windows and Linux
 	text = strings.TrimSuffix(text, "\n")
@@ -237,7 +286,7 @@ func TakeInput() (string, error) {
 	text = strings.TrimSuffix(text, "\r")
 
 	if len(text) == 0 {
-		return "", errors.New("invalid input")
+		return "", errEmptyInput
 	}
 
 	return text, nil
@@ -286,7 +335,7 @@ func GetExeBit(is32 bool, platform string, platforms Platforms, requirements Req
 		}
 	}
 
-	fmt.Printf("* [21/25] %s (32-bit: %v): %s\n", platform, is32, value)
+	Log.Debugf("%s executable (32-bit: %v): %s", platform, is32, value)
 
 	return value
 }
@@ -408,7 +457,7 @@ func (game *Game) parseAvailability(htmlString string) {
 									// 	}
 									// }
 
-									game.AddStore(store, platform, url)
+									game.AddStore(store, platform, url, nil)
 									// fmt.Printf("Store: %s, Platforms: %s, Price Cut: %s, Current: %s, Lowest: %s, Regular: %s\n", store, platform, cut, current, lowest, regular)
 								}
 							}
@@ -492,82 +541,26 @@ func (game *Game) parseReviews(htmlString string) {
 	f(doc)
 }
 
-func (game *Game) AddStore(name, platforms, link string) {
-	validStores := []ValidStore{
-		{
-			ScrapeName:  "Blizzard",
-			DisplayName: "Battle.net",
-			LinkToStrip: "https://us.shop.battle.net/en-us/product/",
-		},
-		{
-			ScrapeName:  "Discord",
-			DisplayName: "Discord",
-			LinkToStrip: "https://discordapp.com/store/skus/",
-		},
-		{
-			ScrapeName:  "Epic Game Store",
-			DisplayName: "Epic Games Store",
-			LinkToStrip: "https://www.epicgames.com/store/en-US/product/",
-		},
-		{
-			ScrapeName:  "GamersGate",
-			DisplayName: "GamersGate",
-			LinkToStrip: "https://www.gamersgate.com/product/",
-		},
-		{
-			ScrapeName:  "GamesPlanet UK",
-			DisplayName: "GamesPlanet",
-			LinkToStrip: "https://uk.gamesplanet.com/game/",
-		},
-		{
-			ScrapeName:  "GOG.com",
-			DisplayName: "GOG.com",
-			LinkToStrip: "https://www.gog.com/game/",
-		},
-		{
-			ScrapeName:  "GreenManGaming",
-			DisplayName: "Green Man Gaming",
-			LinkToStrip: "https://www.greenmangaming.com/games/",
-		},
-		{
-			ScrapeName:  "Humble Store",
-			DisplayName: "Humble",
-			LinkToStrip: "https://www.humblebundle.com/store/",
-		},
-		{
-			ScrapeName:  "Itch.io",
-			DisplayName: "Itch.io",
-			LinkToStrip: "",
-		},
-		{
-			ScrapeName:  "Origin",
-			DisplayName: "Origin",
-			LinkToStrip: "https://www.origin.com/store/",
-		},
-	}
-
-	key := -1
-	for k, v := range validStores {
-		if v.ScrapeName != name {
-			continue
+func (game *Game) AddStore(name, platforms, link string, drm []string) {
+	store, ok := FindStore(name)
+	if !ok {
+		if name != "Steam" && !containsString(game.Data.UnknownStores, name) {
+			game.Data.UnknownStores = append(game.Data.UnknownStores, name)
 		}
-
-		key = k
-		name = validStores[key].DisplayName
-		break
-	}
-
-	if key == -1 {
 		return
 	}
 
 	sanitised := platforms
 	sanitised = strings.Replace(sanitised, "Win", "Windows", 1)
 	sanitised = strings.Replace(sanitised, "Mac", "OS X", 1)
+	if len(strings.TrimSpace(sanitised)) == 0 {
+		sanitised = store.Platforms
+	}
 
-	game.Data.Stores[name] = Store{
+	game.Data.Stores[store.DisplayName] = Store{
 		Platforms: sanitised,
-		URL:       strings.TrimPrefix(link, validStores[key].LinkToStrip),
+		URL:       store.ExtractID(link),
+		DRM:       drm,
 	}
 }
 
@@ -601,20 +594,21 @@ func (game *Game) FindDirectX() string {
 		if version == "10" {
 			version = "11"
 		}
-
-		version += formatCitation("This has been extracted from the game's store page using Steam2PCGW and needs to be confirmed.")
 	}
 
 	return version
 }
 
-func ProcessSpecs(input string, isMin bool) string {
+// Maps the requirements listed by Steam to the parameters of {{System
+// requirements}}. Additional notes are returned apart, the template has a
+// single notes parameter for both levels
+func ProcessSpecs(input string, isMin bool) (string, string) {
 	// Create vars
 	var level string
 	output := input
 
 	if len(output) == 0 {
-		return output
+		return output, ""
 	}
 
 	// Sanitise input and remove HTML tags
@@ -633,12 +627,15 @@ func ProcessSpecs(input string, isMin bool) string {
 	// Determine
 	if isMin {
 		level = "min"
-		output = strings.Replace(output, "Minimum:\n", "", 1)
+		output = strings.Replace(output, "Minimum:", "", 1)
 	} else {
 		level = "rec"
-		output = strings.Replace(output, "Recommended:\n", "", 1)
+		output = strings.Replace(output, "Recommended:", "", 1)
 	}
 
+	// Steam lists the OS alone without a label for some platforms
+	labelled := strings.Contains(output, "OS:")
+
 	// Replace
 	output = strings.Replace(output, "OS:", fmt.Sprintf("|%sOS     = ", level), 1)
 	output = strings.Replace(output, "VR Support:", fmt.Sprintf("|%sother  = ", level), 1)
@@ -690,18 +687,44 @@ func ProcessSpecs(input string, isMin bool) string {
 	}
 
 	output = strings.TrimSuffix(strings.Replace(output, "Memory:", fmt.Sprintf("|%sRAM    = ", level), 1), " ")
-	output = strings.Replace(output, "OS:", fmt.Sprintf("|%sVRAM     = ", level), 1)
 	output = strings.Replace(output, "DirectX:", fmt.Sprintf("|%sDX     = ", level), 1)
 	output = strings.Replace(output, "Sound Card:", fmt.Sprintf("|%saudio  = ", level), 1)
 
-	output = strings.Replace(output, "Additional Notes:", "\n|notes     = {{ii}}", 1)
+	var notes string
+	if index := strings.Index(output, "Additional Notes:"); index != -1 {
+		notes = strings.TrimSpace(output[index+len("Additional Notes:"):])
+		output = output[:index]
+	}
+
+	// Text left without a label is the OS if there was no OS label, the
+	// notes otherwise
+	var params, unlabelled []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); strings.HasPrefix(line, "|") {
+			params = append(params, line)
+		} else if len(line) != 0 {
+			unlabelled = append(unlabelled, line)
+		}
+	}
+
+	if len(unlabelled) != 0 {
+		if labelled {
+			notes = strings.TrimSpace(strings.Join(unlabelled, " ") + " " + notes)
+		} else {
+			params = append([]string{fmt.Sprintf("|%sOS     = %s", level, strings.Join(unlabelled, ", "))}, params...)
+		}
+	}
 
 	// Output
-	return output
+	if len(params) == 0 {
+		return "", notes
+	}
+	return "\n" + strings.Join(params, "\n"), notes
 }
 
 func emptySpecs(level string) string {
-	return fmt.Sprintf(`|%sOS    = 
+	return fmt.Sprintf(`
+|%sOS    = 
 |%sCPU   = 
 |%sCPU2  = 
 |%sRAM   = 
@@ -713,56 +736,52 @@ func emptySpecs(level string) string {
 
 func (game *Game) OutputSpecs() string {
 	var output string = ""
-	var specs string = ""
 
 	if game.Data.Platforms.Windows {
-		output += "\n{{System requirements\n"
-		output += "|OSfamily  = Windows"
-		specs = ProcessSpecs(game.Data.PCRequirements["minimum"].(string), true)
-		output += specs
-
-		// Handle recommended specs
-		if game.Data.PCRequirements["recommended"] != nil {
-			specs = ProcessSpecs(game.Data.PCRequirements["recommended"].(string), false)
-			output += specs
-		} else {
-			output += emptySpecs("rec")
-		}
-		output += "\n}}\n"
+		output += systemRequirements("Windows", game.Data.PCRequirements)
 	}
 
 	if game.Data.Platforms.MAC {
-		output += "\n{{System requirements\n"
-		output += ("|OSfamily  = OS X")
-		specs = ProcessSpecs(game.Data.MACRequirements["minimum"].(string), true)
-		output += specs
-
-		// Handle recommended specs
-		if game.Data.MACRequirements["recommended"] != nil {
-			specs = ProcessSpecs(game.Data.MACRequirements["recommended"].(string), false)
-			output += specs
-		} else {
-			output += emptySpecs("rec")
-		}
-		output += "\n}}\n"
+		output += systemRequirements("OS X", game.Data.MACRequirements)
 	}
 
 	if game.Data.Platforms.Linux {
-		output += "\n{{System requirements\n"
-		output += ("|OSfamily  = Linux")
-		specs = ProcessSpecs(game.Data.LinuxRequirements["minimum"].(string), true)
-		output += specs
-
-		// Handle recommended specs
-		if game.Data.LinuxRequirements["recommended"] != nil {
-			specs = ProcessSpecs(game.Data.LinuxRequirements["recommended"].(string), false)
-			output += specs
-		} else {
-			output += emptySpecs("rec")
+		output += systemRequirements("Linux", game.Data.LinuxRequirements)
+	}
+
+	return output
+}
+
+func systemRequirements(family string, requirements Requirement) string {
+	output := "\n{{System requirements\n"
+	output += "|OSfamily  = " + family
+
+	minimum, _ := requirements["minimum"].(string)
+	specs, minNotes := ProcessSpecs(minimum, true)
+	output += specs
+	if len(specs) == 0 {
+		output += emptySpecs("min")
+	}
+
+	// Handle recommended specs
+	recommended, _ := requirements["recommended"].(string)
+	specs, recNotes := ProcessSpecs(recommended, false)
+	output += specs
+	if len(specs) == 0 {
+		output += emptySpecs("rec")
+	}
+
+	var notes []string
+	for _, note := range []string{minNotes, recNotes} {
+		if len(note) != 0 {
+			notes = append(notes, "{{ii}} "+note)
 		}
-		output += "\n}}\n"
+	}
+	if len(notes) != 0 {
+		output += "\n|notes     = " + strings.Join(notes, "\n")
 	}
 
+	output += "\n}}\n"
 	return output
 }
 
@@ -898,33 +917,21 @@ func (game *Game) SetFranchise(name string) {
 }
 
 func (game *Game) SetPacing(tags []string) {
-	var output string
 	pacing := []string{
 		"Continuous turn-based",
 		"Persistent",
 		"Real-time",
 		"Relaxed",
 		"Turn-based"}
-	for _, pace := range pacing {
-		for _, tag := range tags {
-			if strings.Contains(strings.ToLower(tag), strings.ToLower(pace)) {
-				output += pace + ", "
-				break
-			}
-		}
-	}
+	output := strings.Join(game.matchTags("pacing", tags, pacing), ", ")
 
 	if len(output) == 0 {
 		output += "Real-time"
-	} else {
-		output = strings.TrimSuffix(output, ", ")
-		output = strings.TrimSpace(output)
 	}
 	game.Data.Pacing = output
 }
 
 func (game *Game) SetPerspective(tags []string) {
-	var output string
 	perspectives := []string{
 		"Audio-based",
 		"Bird's-eye view",
@@ -938,21 +945,11 @@ func (game *Game) SetPerspective(tags []string) {
 		"Text-based",
 		"Third-person",
 		"Top-down view"}
-	for _, perspective := range perspectives {
-		for _, tag := range tags {
-			if strings.Contains(strings.ToLower(tag), strings.ToLower(perspective)) {
-				output += perspective + ", "
-				break
-			}
-		}
-	}
-	output = strings.TrimSuffix(output, ", ")
-	output = strings.TrimSpace(output)
+	output := strings.Join(game.matchTags("perspectives", tags, perspectives), ", ")
 	game.Data.Perspectives = output
 }
 
 func (game *Game) SetControls(tags []string) {
-	var output string
 	controls := []string{
 		"Direct control",
 		"Gestures",
@@ -961,26 +958,15 @@ func (game *Game) SetControls(tags []string) {
 		"Point and select",
 		"Text input",
 		"Voice control"}
-	for _, control := range controls {
-		for _, tag := range tags {
-			if strings.Contains(strings.ToLower(tag), strings.ToLower(control)) {
-				output += control + ", "
-				break
-			}
-		}
-	}
+	output := strings.Join(game.matchTags("controls", tags, controls), ", ")
 
 	if len(output) == 0 {
 		output += "Direct control"
-	} else {
-		output = strings.TrimSuffix(output, ", ")
-		output = strings.TrimSpace(output)
 	}
 	game.Data.Controls = output
 }
 
 func (game *Game) SetGenres(tags []string) {
-	var output string
 	genres := []string{
 		"4X",
 		"Action",
@@ -1056,21 +1042,11 @@ func (game *Game) SetGenres(tags []string) {
 		"Visual novel",
 		"Wargame",
 		"Word"}
-	for _, genre := range genres {
-		for _, tag := range tags {
-			if strings.Contains(strings.ToLower(tag), strings.ToLower(genre)) {
-				output += genre + ", "
-				break
-			}
-		}
-	}
-	output = strings.TrimSuffix(output, ", ")
-	output = strings.TrimSpace(output)
+	output := strings.Join(game.matchTags("genres", tags, genres), ", ")
 	game.Data.Genres = output
 }
 
 func (game *Game) SetSports(tags []string) {
-	var output string
 	sports := []string{
 		"American football",
 		"Australian football",
@@ -1107,22 +1083,10 @@ func (game *Game) SetSports(tags []string) {
 		"Volleyball",
 		"Water sports",
 		"Wrestling"}
-	for _, sport := range sports {
-		for _, tag := range tags {
-			if strings.Contains(strings.ToLower(tag), strings.ToLower(sport)) {
-				output += sport + ", "
-				break
-			}
-		}
-	}
-
-	output = strings.TrimSuffix(output, ", ")
-	output = strings.TrimSpace(output)
-	game.Data.Sports = output
+	game.Data.Sports = strings.Join(game.matchTags("sports", tags, sports), ", ")
 }
 
 func (game *Game) SetVehicles(tags []string) {
-	var output string
 	vehicles := []string{
 		"Automobile",
 		"Bicycle",
@@ -1143,22 +1107,10 @@ func (game *Game) SetVehicles(tags []string) {
 		"Train",
 		"Transport",
 		"Truck"}
-	for _, vehicle := range vehicles {
-		for _, tag := range tags {
-			if strings.Contains(strings.ToLower(tag), strings.ToLower(vehicle)) {
-				output += vehicle + ", "
-				break
-			}
-		}
-	}
-
-	output = strings.TrimSuffix(output, ", ")
-	output = strings.TrimSpace(output)
-	game.Data.Vehicles = output
+	game.Data.Vehicles = strings.Join(game.matchTags("vehicles", tags, vehicles), ", ")
 }
 
 func (game *Game) SetArtStyles(tags []string) {
-	var output string
 	artStyles := []string{
 		"Abstract",
 		"Anime",
@@ -1175,26 +1127,15 @@ func (game *Game) SetArtStyles(tags []string) {
 		"Vector art",
 		"Video backdrop",
 		"Voxel art"}
-	for _, artStyle := range artStyles {
-		for _, tag := range tags {
-			if strings.Contains(strings.ToLower(tag), strings.ToLower(artStyle)) {
-				output += artStyle + ", "
-				break
-			}
-		}
-	}
+	output := strings.Join(game.matchTags("art styles", tags, artStyles), ", ")
 
 	if len(output) == 0 {
 		output = "Realistic"
-	} else {
-		output = strings.TrimSuffix(output, ", ")
-		output = strings.TrimSpace(output)
 	}
 	game.Data.ArtStyles = output
 }
 
 func (game *Game) SetThemes(tags []string) {
-	var output string
 	themes := []string{
 		"Adult",
 		"Africa",
@@ -1242,14 +1183,6 @@ func (game *Game) SetThemes(tags []string) {
 		"World War I",
 		"World War II",
 		"Zombies"}
-	for _, theme := range themes {
-		for _, tag := range tags {
-			if strings.Contains(strings.ToLower(tag), strings.ToLower(theme)) {
-				output += theme + ", "
-				break
-			}
-		}
-	}
-	output = strings.TrimSuffix(output, ", ")
+	output := strings.Join(game.matchTags("themes", tags, themes), ", ")
 	game.Data.Themes = output
 }
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Wikidata properties of the infobox fields, Wikipedia comes from the sitelink
+var wikidataProperties = []WikidataProperty{
+	{Field: "hltb", Property: "P2816"},
+	{Field: "igdb", Property: "P5794"},
+	{Field: "lutris", Property: "P7597"},
+	{Field: "mobygames", Property: "P1933"},
+	{Field: "strategywiki", Property: "P9075"},
+	{Field: "winehq", Property: "P600"},
+}
+
+type WikidataClient struct {
+	Endpoint string
+	Client   *http.Client
+}
+
+func NewWikidataClient(config WikidataConfig) *WikidataClient {
+	return &WikidataClient{
+		Endpoint: config.Endpoint,
+		Client:   &http.Client{},
+	}
+}
+
+func wikidataQuery(appId string) string {
+	query := "SELECT ?item ?wikipedia"
+	for _, v := range wikidataProperties {
+		query += " ?" + v.Field
+	}
+
+	query += fmt.Sprintf(" WHERE {\n  ?item wdt:P1733 \"%s\" .\n", appId)
+	for _, v := range wikidataProperties {
+		query += fmt.Sprintf("  OPTIONAL { ?item wdt:%s ?%s . }\n", v.Property, v.Field)
+	}
+	query += "  OPTIONAL { ?wikipedia schema:about ?item ; schema:isPartOf <https://en.wikipedia.org/> . }\n}"
+	return query
+}
+
+// Queries the SPARQL endpoint for the items linked to the Steam app ID
+func (client *WikidataClient) Resolve(appId string) (ids map[string]string, err error) {
+	if !regexp.MustCompile(`^\d+$`).MatchString(appId) {
+		return nil, errors.New("invalid Steam app ID")
+	}
+
+	req, err := http.NewRequest("GET", client.Endpoint+"?"+url.Values{"query": {wikidataQuery(appId)}}.Encode(), nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Accept", "application/sparql-results+json")
+	req.Header.Set("User-Agent", fmt.Sprintf("%s/%s (%s)", strings.ReplaceAll(APP_NAME, " ", ""), VERSION, GH_LINK))
+
+	response, err := client.Client.Do(req)
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("'%s' returned HTTP code %d", client.Endpoint, response.StatusCode)
+	}
+
+	var result WikidataResult
+	if err = json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return
+	}
+
+	ids = make(map[string]string)
+	var items []string
+	for _, binding := range result.Results.Bindings {
+		item := binding["item"].Value
+		if !containsString(items, item) {
+			items = append(items, item)
+		}
+
+		// Only the first item is used, the rest are reported below
+		if item != items[0] {
+			continue
+		}
+
+		for field, value := range binding {
+			if _, ok := ids[field]; ok || field == "item" {
+				continue
+			}
+
+			if field == "wikipedia" {
+				ids[field] = wikipediaTitle(value.Value)
+			} else {
+				ids[field] = value.Value
+			}
+		}
+	}
+
+	if len(items) > 1 {
+		Log.Warnf("Found multiple Wikidata items for the app ID (%s), using %s...", strings.Join(items, ", "), items[0])
+	}
+	if len(items) != 0 {
+		ids["item"] = items[0][strings.LastIndex(items[0], "/")+1:]
+	}
+	return
+}
+
+// The page of the item the IDs were read from, or a search for the items
+// listing the app ID if the cache predates the item being kept
+func (game *Game) wikidataURL(appId string) string {
+	if item := game.Data.ExternalIDs["item"]; len(item) != 0 {
+		return fmt.Sprintf(WIKIDATA_LINK, item)
+	}
+	return fmt.Sprintf(WIKIDATA_SEARCH_LINK, appId)
+}
+
+func wikipediaTitle(link string) string {
+	title := strings.TrimPrefix(link, "https://en.wikipedia.org/wiki/")
+	if unescaped, err := url.PathUnescape(title); err == nil {
+		title = unescaped
+	}
+	return strings.ReplaceAll(title, "_", " ")
+}
+
+// Resolves the external IDs, results are cached alongside the Steam data
+func (game *Game) fetchWikidata(client *WikidataClient, appId string) (err error) {
+	fileName := fmt.Sprintf("cache/%s.wikidata.json", appId)
+
+	var ids map[string]string
+	if doesCacheExistOrLatest(fileName) {
+		var data []byte
+		if data, err = os.ReadFile(fileName); err == nil {
+			err = json.Unmarshal(data, &ids)
+		}
+	} else {
+		ids, err = client.Resolve(appId)
+		if err == nil {
+			data, _ := json.Marshal(ids)
+			if writeErr := os.WriteFile(fileName, data, 0777); writeErr != nil {
+				Log.Warnf("Failed to cache the Wikidata IDs... (%s)", writeErr)
+			}
+		}
+	}
+
+	if err != nil {
+		return
+	}
+
+	game.Data.ExternalIDs = ids
+	return
+}
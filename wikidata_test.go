@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// Stand-in SPARQL endpoint, app 620 is listed by two items
+func newWikidataStandIn(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/sparql-results+json" {
+			http.Error(w, "unsupported format", http.StatusBadRequest)
+			return
+		}
+
+		if !strings.Contains(r.URL.Query().Get("query"), `?item wdt:P1733 "620"`) {
+			w.Write([]byte(`{"results": {"bindings": []}}`))
+			return
+		}
+		w.Write([]byte(`{"results": {"bindings": [
+			{"item": {"type": "uri", "value": "http://www.wikidata.org/entity/Q279744"}, "wikipedia": {"type": "uri", "value": "https://en.wikipedia.org/wiki/Portal_2"}, "hltb": {"type": "literal", "value": "7231"}},
+			{"item": {"type": "uri", "value": "http://www.wikidata.org/entity/Q279744"}, "mobygames": {"type": "literal", "value": "portal-2"}},
+			{"item": {"type": "uri", "value": "http://www.wikidata.org/entity/Q999"}, "hltb": {"type": "literal", "value": "1"}, "lutris": {"type": "literal", "value": "other-game"}}
+		]}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWikidataResolve(t *testing.T) {
+	client := NewWikidataClient(WikidataConfig{Endpoint: newWikidataStandIn(t).URL})
+
+	var buffer bytes.Buffer
+	output := Log.Output
+	Log.Output = &buffer
+	defer func() { Log.Output = output }()
+
+	ids, err := client.Resolve("620")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{"item": "Q279744", "wikipedia": "Portal 2", "hltb": "7231", "mobygames": "portal-2"}
+	if len(ids) != len(want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+	for field, value := range want {
+		if ids[field] != value {
+			t.Errorf("%s = %q, want %q", field, ids[field], value)
+		}
+	}
+
+	if !strings.Contains(buffer.String(), "Found multiple Wikidata items") || !strings.Contains(buffer.String(), "using http://www.wikidata.org/entity/Q279744") {
+		t.Errorf("no warning about the second item, log:\n%s", buffer.String())
+	}
+
+	if ids, err = client.Resolve("400"); err != nil || len(ids) != 0 {
+		t.Errorf("Resolve(400) = %v, %v, want no IDs", ids, err)
+	}
+	if _, err = client.Resolve("620 OR 1"); err == nil {
+		t.Error("an invalid app ID was queried")
+	}
+}
+
+func TestFetchWikidataCache(t *testing.T) {
+	client := NewWikidataClient(WikidataConfig{Endpoint: newWikidataStandIn(t).URL})
+	chdirTemp(t)
+
+	// Without a cache directory the IDs are still used
+	game := newTestGame("Portal 2")
+	if err := game.fetchWikidata(client, "620"); err != nil || game.Data.ExternalIDs["item"] != "Q279744" {
+		t.Fatalf("fetchWikidata without a cache = %v, %v", game.Data.ExternalIDs, err)
+	}
+
+	os.Mkdir("cache", 0777)
+	game = newTestGame("Portal 2")
+	if err := game.fetchWikidata(client, "620"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat("cache/620.wikidata.json"); err != nil {
+		t.Fatalf("the IDs were not cached: %s", err)
+	}
+
+	// The cache is read back rather than querying again
+	client.Endpoint = "http://127.0.0.1:0"
+	game = newTestGame("Portal 2")
+	if err := game.fetchWikidata(client, "620"); err != nil || game.Data.ExternalIDs["wikipedia"] != "Portal 2" {
+		t.Errorf("fetchWikidata from the cache = %v, %v", game.Data.ExternalIDs, err)
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var wikiCommentRegex = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+// Every template call in the text, nested ones included, ordered by their
+// opening braces
+func ParseTemplates(text string) (templates []WikiTemplate) {
+	for i := 0; i < len(text); {
+		if strings.HasPrefix(text[i:], "<!--") {
+			i = skipComment(text, i)
+		} else if strings.HasPrefix(text[i:], "{{") {
+			end, found := scanTemplate(text, i, &templates)
+			if !found {
+				// Unclosed braces are plain text
+				end = i + 2
+			}
+			i = end
+		} else {
+			i++
+		}
+	}
+	return
+}
+
+func skipComment(text string, start int) int {
+	end := strings.Index(text[start:], "-->")
+	if end == -1 {
+		return len(text)
+	}
+	return start + end + 3
+}
+
+// Scans the template opened at start, appending it and the templates nested
+// within it. Returns the offset right after its closing braces
+func scanTemplate(text string, start int, templates *[]WikiTemplate) (int, bool) {
+	index := len(*templates)
+	*templates = append(*templates, WikiTemplate{Start: start})
+
+	var segments [][3]int // Start, end and offset of the first = of each segment
+	segment := [3]int{start + 2, 0, -1}
+	links := 0
+
+	for i := start + 2; i < len(text); {
+		switch {
+		case strings.HasPrefix(text[i:], "<!--"):
+			i = skipComment(text, i)
+		case strings.HasPrefix(text[i:], "{{"):
+			end, found := scanTemplate(text, i, templates)
+			if !found {
+				// An unclosed nested template leaves this one unclosed too
+				*templates = (*templates)[:index]
+				return 0, false
+			}
+			i = end
+		case strings.HasPrefix(text[i:], "[["):
+			links++
+			i += 2
+		case strings.HasPrefix(text[i:], "]]") && links > 0:
+			links--
+			i += 2
+		case strings.HasPrefix(text[i:], "}}"):
+			segment[1] = i
+			segments = append(segments, segment)
+
+			template := &(*templates)[index]
+			template.End = i + 2
+			template.Name = strings.TrimSpace(stripComments(text[segments[0][0]:segments[0][1]]))
+			template.Params = wikiParams(text, segments[1:])
+			return i + 2, true
+		case text[i] == '|' && links == 0:
+			segment[1] = i
+			segments = append(segments, segment)
+			segment = [3]int{i + 1, 0, -1}
+			i++
+		case text[i] == '=' && segment[2] == -1 && links == 0:
+			segment[2] = i
+			i++
+		default:
+			i++
+		}
+	}
+
+	// Unclosed template
+	*templates = (*templates)[:index]
+	return 0, false
+}
+
+func wikiParams(text string, segments [][3]int) (params []WikiParam) {
+	position := 0
+	for _, segment := range segments {
+		param := WikiParam{Start: segment[0], ValueStart: segment[0], ValueEnd: segment[1]}
+		if segment[2] != -1 {
+			param.Name = strings.TrimSpace(stripComments(text[segment[0]:segment[2]]))
+			param.ValueStart = segment[2] + 1
+		} else {
+			position++
+			param.Name = strconv.Itoa(position)
+		}
+
+		param.Value = strings.TrimSpace(stripComments(text[param.ValueStart:param.ValueEnd]))
+		params = append(params, param)
+	}
+	return
+}
+
+func stripComments(text string) string {
+	return wikiCommentRegex.ReplaceAllString(text, "")
+}
+
+// Template names are case-insensitive on their first letter and treat
+// underscores as spaces
+func templateNameIs(name, expected string) bool {
+	return strings.EqualFold(strings.ReplaceAll(name, "_", " "), expected)
+}
+
+func (template *WikiTemplate) Param(name string) *WikiParam {
+	for i := range template.Params {
+		if template.Params[i].Name == name {
+			return &template.Params[i]
+		}
+	}
+	return nil
+}
+
+// An edit replacing the value of the parameter, keeping the whitespace around
+// it. Comments are kept when filling an empty value
+func (param *WikiParam) Replace(text, value string) WikiEdit {
+	raw := text[param.ValueStart:param.ValueEnd]
+
+	if len(param.Value) == 0 {
+		start := param.ValueStart + len(raw) - len(strings.TrimLeft(raw, " \t"))
+		return WikiEdit{Start: start, End: start, Text: value}
+	}
+
+	start := param.ValueStart + len(raw) - len(strings.TrimLeft(raw, " \t\r\n"))
+	end := param.ValueStart + len(strings.TrimRight(raw, " \t\r\n"))
+	return WikiEdit{Start: start, End: end, Text: value}
+}
+
+// An edit adding the text right after the value of the parameter
+func (param *WikiParam) Append(text, value string) WikiEdit {
+	if len(param.Value) == 0 {
+		return param.Replace(text, value)
+	}
+
+	end := param.ValueStart + len(strings.TrimRight(text[param.ValueStart:param.ValueEnd], " \t\r\n"))
+	return WikiEdit{Start: end, End: end, Text: value}
+}
+
+// Applies the edits in order of their offsets, insertions at the same offset
+// are written in the given order before any replacement starting there.
+// Overlapping edits are an error
+func ApplyEdits(text string, edits []WikiEdit) (string, error) {
+	sorted := make([]WikiEdit, len(edits))
+	copy(sorted, edits)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Start != sorted[j].Start {
+			return sorted[i].Start < sorted[j].Start
+		}
+		return sorted[i].End == sorted[i].Start && sorted[j].End != sorted[j].Start
+	})
+
+	var output strings.Builder
+	cursor := 0
+	for _, edit := range sorted {
+		if edit.Start < cursor || edit.End < edit.Start || edit.End > len(text) {
+			return text, fmt.Errorf("the edit of %d-%d overlaps another edit or is out of range", edit.Start, edit.End)
+		}
+		output.WriteString(text[cursor:edit.Start])
+		output.WriteString(edit.Text)
+		cursor = edit.End
+	}
+	output.WriteString(text[cursor:])
+	return output.String(), nil
+}
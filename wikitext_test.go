@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+func TestApplyEdits(t *testing.T) {
+	text := "{{Input|a=1|b=2}}"
+
+	tests := []struct {
+		name  string
+		edits []WikiEdit
+		want  string
+	}{
+		{"none", nil, text},
+		{"sorted", []WikiEdit{{Start: 10, End: 11, Text: "x"}, {Start: 14, End: 15, Text: "y"}}, "{{Input|a=x|b=y}}"},
+		{"unsorted", []WikiEdit{{Start: 14, End: 15, Text: "y"}, {Start: 10, End: 11, Text: "x"}}, "{{Input|a=x|b=y}}"},
+		{"insertions at one offset", []WikiEdit{{Start: 11, End: 11, Text: "<ref/>"}, {Start: 11, End: 11, Text: "<ref2/>"}}, "{{Input|a=1<ref/><ref2/>|b=2}}"},
+		{"insertion before a replacement", []WikiEdit{{Start: 10, End: 11, Text: "x"}, {Start: 10, End: 10, Text: "<!-- -->"}}, "{{Input|a=<!-- -->x|b=2}}"},
+		{"adjacent", []WikiEdit{{Start: 10, End: 11, Text: "x"}, {Start: 11, End: 12, Text: ";"}}, "{{Input|a=x;b=2}}"},
+	}
+
+	for _, test := range tests {
+		got, err := ApplyEdits(text, test.edits)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+		} else if got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestApplyEditsOverlap(t *testing.T) {
+	text := "{{Input|a=1|b=2}}"
+
+	for _, edits := range [][]WikiEdit{
+		{{Start: 8, End: 11, Text: "a=x"}, {Start: 10, End: 13, Text: "y"}},
+		{{Start: 10, End: 13, Text: "y"}, {Start: 8, End: 11, Text: "a=x"}},
+		{{Start: 8, End: 16, Text: ""}, {Start: 12, End: 12, Text: "c=3|"}},
+		{{Start: 12, End: 10, Text: "x"}},
+		{{Start: 16, End: 40, Text: "x"}},
+	} {
+		if got, err := ApplyEdits(text, edits); err == nil {
+			t.Errorf("ApplyEdits(%v) = %q, want an error", edits, got)
+		}
+	}
+}